@@ -2,5 +2,11 @@ package goforgo
 
 import "embed"
 
-//go:embed exercises solutions
+//go:embed exercises solutions tracks
 var Content embed.FS
+
+// Modules holds goforgo's own go.mod and go.sum: the exact dependency set the
+// third-party library exercises are written against.
+//
+//go:embed go.mod go.sum
+var Modules embed.FS
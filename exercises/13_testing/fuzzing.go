@@ -0,0 +1,24 @@
+// fuzzing.go
+// Learn fuzz testing with testing.F
+//
+// Reverse below looks correct for ASCII input, but it hides a bug.
+// Write the fuzz target in fuzzing_test.go, let the fuzzer find an
+// input that breaks Reverse, then fix it.
+
+package main
+
+import "fmt"
+
+// Reverse returns s with its characters in reverse order
+func Reverse(s string) string {
+	// TODO: This swaps bytes, not characters. Fix it once the fuzzer shows you why.
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+func main() {
+	fmt.Println(Reverse("Hello, Gopher!"))
+}
@@ -0,0 +1,21 @@
+// fuzzing_test.go
+// Fuzz target for Reverse
+
+package main
+
+import (
+	"testing"
+)
+
+func FuzzReverse(f *testing.F) {
+	// Seed inputs. More seeds live in testdata/fuzz/FuzzReverse.
+	f.Add("hello")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// TODO: Reverse s twice and check that you get s back
+
+		// TODO: If s is valid UTF-8 (unicode/utf8.ValidString), check that
+		// the reversed string is valid UTF-8 too
+	})
+}
@@ -0,0 +1,34 @@
+// Package inventory tracks stock levels by item name.
+package inventory
+
+// Inventory holds the quantity of each item
+type Inventory struct {
+	stock map[string]int
+}
+
+// New returns an empty inventory
+func New() *Inventory {
+	return &Inventory{stock: make(map[string]int)}
+}
+
+// Add increases the quantity of an item
+func (inv *Inventory) Add(name string, quantity int) {
+	// TODO: Add quantity to the stock of name
+}
+
+// Items returns item names in alphabetical order
+func (inv *Inventory) Items() []string {
+	// TODO: Collect the keys of stock and sort them
+	return nil
+}
+
+// Quantity returns the stock of a single item
+func (inv *Inventory) Quantity(name string) int {
+	return inv.stock[name]
+}
+
+// Total returns the combined stock of all items
+func (inv *Inventory) Total() int {
+	// TODO: Sum every quantity in stock
+	return 0
+}
@@ -0,0 +1,28 @@
+// main.go
+// Learn how a Go program is split across packages
+//
+// This exercise is a directory: main.go is the entry point and the
+// inventory package lives in internal/inventory. Only code inside this
+// module can import an internal package.
+
+package main
+
+import (
+	"fmt"
+	// TODO: Import the inventory package using its module path:
+	// "goforgo/47_code_organization/package_layout/internal/inventory"
+)
+
+func main() {
+	fmt.Println("=== Package Layout ===")
+
+	// TODO: Create an inventory with inventory.New()
+
+	// TODO: Add apple (5), banana (2) and cherry (9)
+
+	// TODO: Print "Added N items" using the number of items in the inventory
+
+	// TODO: Print each item as "name: quantity", in the order returned by Items()
+
+	// TODO: Print "Total stock: N" using Total()
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	goanalysis "golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/passes/deepequalerrors"
+	"golang.org/x/tools/go/analysis/passes/nilness"
+	"golang.org/x/tools/go/analysis/passes/reflectvaluecompare"
+	"golang.org/x/tools/go/analysis/passes/sortslice"
+	"golang.org/x/tools/go/analysis/passes/unusedwrite"
+	"golang.org/x/tools/go/analysis/passes/waitgroup"
+	"golang.org/x/tools/go/packages"
+)
+
+// Curated returns the quality gate's analyzers: mistakes learners commonly make that
+// go vet's default set doesn't report, or only reports with newer toolchains.
+func Curated() []*goanalysis.Analyzer {
+	return []*goanalysis.Analyzer{
+		deepequalerrors.Analyzer,
+		nilness.Analyzer,
+		reflectvaluecompare.Analyzer,
+		sortslice.Analyzer,
+		unusedwrite.Analyzer,
+		waitgroup.Analyzer,
+	}
+}
+
+// RunAnalyzers runs analyzers in-process over the packages matched by patterns,
+// resolved relative to dir, and returns the diagnostics reported for those packages.
+func RunAnalyzers(dir string, env []string, analyzers []*goanalysis.Analyzer, patterns ...string) ([]Diagnostic, error) {
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax,
+		Dir:  dir,
+		Env:  append(os.Environ(), env...),
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	var errs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			errs = append(errs, e.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to type-check: %s", strings.Join(errs, "; "))
+	}
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	var diags []Diagnostic
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act.Analyzer.Name, act.Err)
+		}
+		for _, d := range act.Diagnostics {
+			diags = append(diags, Diagnostic{
+				Analyzer: act.Analyzer.Name,
+				Posn:     act.Package.Fset.Position(d.Pos).String(),
+				Message:  d.Message,
+				Category: d.Category,
+			})
+		}
+	}
+	return diags, nil
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is one finding in the JSON that go vet -json and analysis drivers
+// (singlechecker, multichecker) print:
+//
+//	{"pkg/path": {"analyzer": [{"posn": "file.go:12:3", "message": "..."}]}}
+type Diagnostic struct {
+	Analyzer string `json:"-"`
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+	Category string `json:"category,omitempty"`
+}
+
+// ParseDiagnostics reads every JSON diagnostic tree in output. Lines starting with #,
+// which go vet prints before each package, are skipped. Analyzers that failed on a
+// package are reported as an error.
+func ParseDiagnostics(output []byte) ([]Diagnostic, error) {
+	var body bytes.Buffer
+	for _, line := range bytes.SplitAfter(output, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte("#")) {
+			body.Write(line)
+		}
+	}
+
+	var diags []Diagnostic
+	var failures []string
+	dec := json.NewDecoder(&body)
+	for {
+		var tree map[string]map[string]json.RawMessage
+		err := dec.Decode(&tree)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("analyzer output is not JSON diagnostics: %s", strings.TrimSpace(string(output)))
+		}
+
+		for _, pkg := range sortedKeys(tree) {
+			for _, analyzer := range sortedKeys(tree[pkg]) {
+				raw := tree[pkg][analyzer]
+				var list []Diagnostic
+				if err := json.Unmarshal(raw, &list); err != nil {
+					var failure struct {
+						Error string `json:"error"`
+					}
+					if json.Unmarshal(raw, &failure) != nil || failure.Error == "" {
+						return nil, fmt.Errorf("unexpected %s diagnostics for %s: %s", analyzer, pkg, raw)
+					}
+					failures = append(failures, fmt.Sprintf("%s: %s", analyzer, failure.Error))
+					continue
+				}
+				for _, d := range list {
+					d.Analyzer = analyzer
+					diags = append(diags, d)
+				}
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return nil, errors.New(strings.Join(failures, "; "))
+	}
+	return diags, nil
+}
+
+// Finding converts the diagnostic to a Finding, prefixing the message with its analyzer
+func (d Diagnostic) Finding() Finding {
+	return Finding{Pos: parsePosn(d.Posn), Message: d.Analyzer + ": " + d.Message}
+}
+
+// parsePosn parses file:line:col or file:line. File names may themselves contain colons.
+func parsePosn(posn string) token.Position {
+	pos := token.Position{Filename: posn}
+	rest := posn
+	var nums []int
+	for len(nums) < 2 {
+		i := strings.LastIndex(rest, ":")
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(rest[i+1:])
+		if err != nil {
+			break
+		}
+		nums = append([]int{n}, nums...)
+		rest = rest[:i]
+	}
+	switch len(nums) {
+	case 2:
+		pos.Filename, pos.Line, pos.Column = rest, nums[0], nums[1]
+	case 1:
+		pos.Filename, pos.Line = rest, nums[0]
+	}
+	return pos
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	// go vet -json prints a header and a tree per package
+	output := `# example.com/ex
+{
+	"example.com/ex": {
+		"printf": [
+			{
+				"posn": "/work/ex/main.go:7:2",
+				"message": "fmt.Printf format %d has arg s of wrong type string"
+			}
+		],
+		"shadow": [
+			{
+				"posn": "/work/ex/util.go:12",
+				"message": "declaration of \"err\" shadows declaration at line 9"
+			}
+		]
+	}
+}
+# example.com/ex/internal
+{}
+`
+	diags, err := ParseDiagnostics([]byte(output))
+	if err != nil {
+		t.Fatalf("ParseDiagnostics returned error: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %+v", diags)
+	}
+
+	if got := diags[0].Finding().String(); got != "main.go:7:2: printf: fmt.Printf format %d has arg s of wrong type string" {
+		t.Errorf("unexpected first finding %q", got)
+	}
+	if pos := diags[1].Finding().Pos; pos.Filename != "/work/ex/util.go" || pos.Line != 12 || pos.Column != 0 {
+		t.Errorf("unexpected position for file:line %+v", pos)
+	}
+}
+
+func TestParseDiagnostics_Errors(t *testing.T) {
+	_, err := ParseDiagnostics([]byte(`{"example.com/ex": {"nilness": {"error": "analysis failed"}}}`))
+	if err == nil || !strings.Contains(err.Error(), "nilness: analysis failed") {
+		t.Errorf("expected the analyzer failure to be reported, got %v", err)
+	}
+
+	_, err = ParseDiagnostics([]byte("main.go:3:1: undefined: x\n"))
+	if err == nil || !strings.Contains(err.Error(), "undefined: x") {
+		t.Errorf("expected non-JSON output to be reported, got %v", err)
+	}
+}
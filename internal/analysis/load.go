@@ -0,0 +1,207 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Package is an exercise's type-checked source, loaded with go/packages. Exercises
+// spanning several packages are merged into one, so checks can look at all of them.
+type Package struct {
+	Fset  *token.FileSet
+	Files []*ast.File
+	Types []*types.Package // Every loaded package, in load order
+	Info  *types.Info      // Type information for all Files
+}
+
+// Finding is one place in the code where a check failed
+type Finding struct {
+	Pos     token.Position
+	Message string
+}
+
+// String renders the finding as file:line:col: message, using the file's base name
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", filepath.Base(f.Pos.Filename), f.Pos.Line, f.Pos.Column, f.Message)
+}
+
+// Args configures a check, e.g. {"path": "fmt"} for forbid_import
+type Args map[string]string
+
+// Require returns an error naming the first of keys that isn't set
+func (a Args) Require(keys ...string) error {
+	for _, key := range keys {
+		if a[key] == "" {
+			return fmt.Errorf("missing required argument %q", key)
+		}
+	}
+	return nil
+}
+
+// TypedCheck is a StaticCheck that works on type-checked packages rather than a single
+// parsed file. The runner loads the exercise and calls Check instead of Execute.
+type TypedCheck interface {
+	StaticCheck
+	Check(pkg *Package, args Args) (bool, string, error)
+}
+
+// loadMode is what typed checks need: syntax trees with full type information. Dependencies
+// are type-checked from source rather than export data, which ties the loader to the
+// toolchain that wrote it.
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// Load type-checks the packages matched by patterns, which are resolved relative to dir
+// like go build arguments: file paths for single-file exercises, ./... for packages.
+// env holds extra environment variables for the underlying go list.
+func Load(dir string, env []string, patterns ...string) (*Package, error) {
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  dir,
+		Env:  append(os.Environ(), env...),
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages found for %s", strings.Join(patterns, " "))
+	}
+
+	var errs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			errs = append(errs, e.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to type-check: %s", strings.Join(errs, "; "))
+	}
+
+	pkg := &Package{
+		Fset: pkgs[0].Fset,
+		Info: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+			Instances:  make(map[*ast.Ident]types.Instance),
+		},
+	}
+	for _, p := range pkgs {
+		pkg.Files = append(pkg.Files, p.Syntax...)
+		pkg.Types = append(pkg.Types, p.Types)
+		mergeInfo(pkg.Info, p.TypesInfo)
+	}
+	return pkg, nil
+}
+
+// mergeInfo copies the maps of src that checks use into dst. Keys are syntax nodes,
+// which are unique across packages, so nothing is overwritten.
+func mergeInfo(dst, src *types.Info) {
+	for k, v := range src.Types {
+		dst.Types[k] = v
+	}
+	for k, v := range src.Defs {
+		dst.Defs[k] = v
+	}
+	for k, v := range src.Uses {
+		dst.Uses[k] = v
+	}
+	for k, v := range src.Selections {
+		dst.Selections[k] = v
+	}
+	for k, v := range src.Instances {
+		dst.Instances[k] = v
+	}
+}
+
+// ExecuteFile runs a typed check against a single file with no arguments. Typed checks
+// use it to implement Execute.
+func ExecuteFile(check TypedCheck, filePath string) (bool, string, error) {
+	pkg, err := Load(filepath.Dir(filePath), nil, filePath)
+	if err != nil {
+		return false, "", err
+	}
+	return check.Check(pkg, Args{})
+}
+
+// Position returns the position of pos in the loaded package
+func (p *Package) Position(pos token.Pos) token.Position {
+	return p.Fset.Position(pos)
+}
+
+// Callee returns the function or method called by call, or nil for calls of
+// function values, conversions and builtins.
+func (p *Package) Callee(call *ast.CallExpr) *types.Func {
+	fun := ast.Unparen(call.Fun)
+	switch f := fun.(type) {
+	case *ast.IndexExpr: // Explicitly instantiated generic function
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+	fn, _ := p.Info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// FuncName returns fn's name as checks accept it in arguments: package name and
+// function, e.g. "errors.Is", or package, type and method, e.g. "strings.Builder.WriteString".
+func FuncName(fn *types.Func) string {
+	pkg := ""
+	if fn.Pkg() != nil {
+		pkg = fn.Pkg().Name() + "."
+	}
+	sig, _ := fn.Type().(*types.Signature)
+	if sig == nil || sig.Recv() == nil {
+		return pkg + fn.Name()
+	}
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); ok {
+		return pkg + named.Obj().Name() + "." + fn.Name()
+	}
+	return pkg + fn.Name()
+}
+
+// Report renders a check's findings, sorted by position, or success when there are none
+func Report(success string, findings []Finding) (bool, string) {
+	if len(findings) == 0 {
+		return true, "✅ " + success
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].Pos, findings[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	lines := make([]string, len(findings))
+	for i, f := range findings {
+		lines[i] = "❌ " + f.String()
+	}
+	return false, strings.Join(lines, "\n")
+}
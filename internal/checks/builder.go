@@ -0,0 +1,122 @@
+package checks
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&builderInLoopCheck{})
+}
+
+type builderInLoopCheck struct{}
+
+func (c *builderInLoopCheck) Name() string {
+	return "builder_in_loop"
+}
+
+func (c *builderInLoopCheck) Description() string {
+	return "Checks that strings built in loops use strings.Builder rather than += (args: optional func, which must use a builder in a loop)."
+}
+
+func (c *builderInLoopCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *builderInLoopCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	decls, err := funcDecls(pkg, args["func"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	var findings []analysis.Finding
+	for _, fn := range decls {
+		usesBuilder := false
+		var loops []ast.Node
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if n == nil {
+				loops = loops[:len(loops)-1]
+				return true
+			}
+			loops = append(loops, n)
+			if !isInLoop(loops) {
+				return true
+			}
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if isStringConcat(pkg, node) {
+					findings = append(findings, analysis.Finding{
+						Pos:     pkg.Position(node.Pos()),
+						Message: "string concatenation in a loop copies the string every iteration; use strings.Builder",
+					})
+				}
+			case *ast.CallExpr:
+				if callee := pkg.Callee(node); callee != nil && isBuilderMethod(callee) {
+					usesBuilder = true
+				}
+			}
+			return true
+		})
+		if args["func"] != "" && !usesBuilder {
+			findings = append(findings, analysis.Finding{
+				Pos:     pkg.Position(fn.Name.Pos()),
+				Message: fmt.Sprintf("%s should build its string with strings.Builder inside a loop", declName(fn)),
+			})
+		}
+	}
+	ok, output := analysis.Report("Strings are built with strings.Builder.", findings)
+	return ok, output, nil
+}
+
+// isInLoop reports whether the innermost node of stack sits inside a loop body. Function
+// literals start a new scope: a closure defined in a loop doesn't run once per iteration.
+func isInLoop(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.FuncLit); ok {
+			return false
+		}
+		if isLoop(stack[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStringConcat reports whether assign is s += x or s = s + x on a string
+func isStringConcat(pkg *analysis.Package, assign *ast.AssignStmt) bool {
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false
+	}
+	t := pkg.Info.TypeOf(assign.Lhs[0])
+	if t == nil {
+		return false
+	}
+	if basic, ok := t.Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+		return false
+	}
+	switch assign.Tok {
+	case token.ADD_ASSIGN:
+		return true
+	case token.ASSIGN:
+		bin, ok := assign.Rhs[0].(*ast.BinaryExpr)
+		return ok && bin.Op == token.ADD && types.ExprString(bin.X) == types.ExprString(assign.Lhs[0])
+	}
+	return false
+}
+
+func isBuilderMethod(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "strings" && named.Obj().Name() == "Builder"
+}
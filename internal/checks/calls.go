@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&mustCallCheck{})
+}
+
+type mustCallCheck struct{}
+
+func (c *mustCallCheck) Name() string {
+	return "must_call"
+}
+
+func (c *mustCallCheck) Description() string {
+	return `Checks that a function is called, e.g. "errors.Is" or "strings.Builder.WriteString" (args: func, optional in).`
+}
+
+func (c *mustCallCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *mustCallCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	if err := args.Require("func"); err != nil {
+		return false, "", err
+	}
+	decls, err := funcDecls(pkg, args["in"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	for _, fn := range decls {
+		found := false
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && !found {
+				if callee := pkg.Callee(call); callee != nil && analysis.FuncName(callee) == args["func"] {
+					found = true
+				}
+			}
+			return !found
+		})
+		if found {
+			return true, fmt.Sprintf("✅ %s calls %s.", declName(fn), args["func"]), nil
+		}
+	}
+
+	where := "The code"
+	if args["in"] != "" {
+		where = args["in"]
+	}
+	return false, fmt.Sprintf("❌ %s never calls %s.", where, args["func"]), nil
+}
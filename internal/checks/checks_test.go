@@ -0,0 +1,230 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+// loadSource type-checks src as main.go in a throwaway module
+func loadSource(t *testing.T, src string) *analysis.Package {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/ex\n\ngo 1.24\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := analysis.Load(dir, []string{"GOFLAGS=-mod=mod", "GOWORK=off"}, "./...")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return pkg
+}
+
+func TestTypedChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		check string
+		args  analysis.Args
+		src   string
+		want  bool
+		out   string // Substring of the output
+	}{
+		{
+			name:  "constraint found",
+			check: "uses_generic_constraint",
+			args:  analysis.Args{"constraint": "cmp.Ordered", "func": "Max"},
+			src: `package main
+import "cmp"
+func Max[T cmp.Ordered](a, b T) T { if a > b { return a }; return b }
+func main() { _ = Max(1, 2) }`,
+			want: true,
+		},
+		{
+			name:  "local constraint",
+			check: "uses_generic_constraint",
+			args:  analysis.Args{"constraint": "Number"},
+			src: `package main
+type Number interface{ ~int | ~float64 }
+func Sum[T Number](xs []T) (s T) { for _, x := range xs { s += x }; return }
+func main() { _ = Sum([]int{1}) }`,
+			want: true,
+		},
+		{
+			name:  "constraint is any",
+			check: "uses_generic_constraint",
+			args:  analysis.Args{"constraint": "comparable"},
+			src: `package main
+func First[T any](xs []T) T { return xs[0] }
+func main() { _ = First([]int{1}) }`,
+			want: false,
+			out:  "First[T any]",
+		},
+		{
+			name:  "pointer receiver",
+			check: "pointer_receiver",
+			args:  analysis.Args{"type": "Counter", "method": "Inc"},
+			src: `package main
+type Counter struct{ n int }
+func (c *Counter) Inc() { c.n++ }
+func main() { var c Counter; c.Inc() }`,
+			want: true,
+		},
+		{
+			name:  "value receiver",
+			check: "pointer_receiver",
+			args:  analysis.Args{"type": "Counter", "method": "Inc"},
+			src: `package main
+type Counter struct{ n int }
+func (c Counter) Inc() { c.n++ }
+func main() { var c Counter; c.Inc() }`,
+			want: false,
+			out:  "main.go:3:",
+		},
+		{
+			name:  "forbidden import",
+			check: "forbid_import",
+			args:  analysis.Args{"path": "fmt"},
+			src: `package main
+import "fmt"
+func main() { fmt.Println() }`,
+			want: false,
+			out:  `package "fmt"`,
+		},
+		{
+			name:  "error wrapped with %v",
+			check: "wraps_errors",
+			src: `package main
+import ("errors"; "fmt")
+func load() error { return fmt.Errorf("load: %v", errors.New("boom")) }
+func main() { _ = load() }`,
+			want: false,
+			out:  "main.go:3:",
+		},
+		{
+			name:  "error wrapped with %w",
+			check: "wraps_errors",
+			src: `package main
+import ("errors"; "fmt")
+func load(id int) error { return fmt.Errorf("load %d: %w", id, errors.New("boom")) }
+func main() { _ = load(1) }`,
+			want: true,
+		},
+		{
+			name:  "context not first",
+			check: "context_first",
+			src: `package main
+import "context"
+func fetch(id int, ctx context.Context) error { return ctx.Err() }
+func main() { _ = fetch(1, context.Background()) }`,
+			want: false,
+			out:  "parameter 2",
+		},
+		{
+			name:  "context missing from named func",
+			check: "context_first",
+			args:  analysis.Args{"func": "fetch"},
+			src: `package main
+func fetch(id int) error { return nil }
+func main() { _ = fetch(1) }`,
+			want: false,
+		},
+		{
+			name:  "naked goroutine",
+			check: "no_naked_goroutine",
+			src: `package main
+func main() { go func() {}() }`,
+			want: false,
+			out:  "main starts a goroutine",
+		},
+		{
+			name:  "goroutine with WaitGroup",
+			check: "no_naked_goroutine",
+			src: `package main
+import "sync"
+func main() { var wg sync.WaitGroup; wg.Add(1); go func() { defer wg.Done() }(); wg.Wait() }`,
+			want: true,
+		},
+		{
+			name:  "goroutine with channel",
+			check: "no_naked_goroutine",
+			src: `package main
+func main() { done := make(chan struct{}); go func() { close(done) }(); <-done }`,
+			want: true,
+		},
+		{
+			name:  "concatenation in loop",
+			check: "builder_in_loop",
+			src: `package main
+func join(xs []string) string { s := ""; for _, x := range xs { s += x }; return s }
+func main() { _ = join(nil) }`,
+			want: false,
+			out:  "use strings.Builder",
+		},
+		{
+			name:  "builder in loop",
+			check: "builder_in_loop",
+			args:  analysis.Args{"func": "join"},
+			src: `package main
+import "strings"
+func join(xs []string) string { var b strings.Builder; for _, x := range xs { b.WriteString(x) }; return b.String() }
+func main() { _ = join(nil) }`,
+			want: true,
+		},
+		{
+			name:  "must call",
+			check: "must_call",
+			args:  analysis.Args{"func": "errors.Is", "in": "main"},
+			src: `package main
+import ("errors"; "io")
+func main() { _ = errors.Is(io.EOF, io.EOF) }`,
+			want: true,
+		},
+		{
+			name:  "must call method",
+			check: "must_call",
+			args:  analysis.Args{"func": "strings.Builder.WriteString"},
+			src: `package main
+func main() {}`,
+			want: false,
+			out:  "never calls",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, ok := analysis.GetCheck(tt.check)
+			if !ok {
+				t.Fatalf("check %s not registered", tt.check)
+			}
+			typed, ok := check.(analysis.TypedCheck)
+			if !ok {
+				t.Fatalf("check %s is not a typed check", tt.check)
+			}
+
+			got, output, err := typed.Check(loadSource(t, tt.src), tt.args)
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %v, want %v; output:\n%s", got, tt.want, output)
+			}
+			if !strings.Contains(output, tt.out) {
+				t.Errorf("output %q does not contain %q", output, tt.out)
+			}
+		})
+	}
+}
+
+func TestTypedChecks_MissingArgs(t *testing.T) {
+	check, _ := analysis.GetCheck("forbid_import")
+	pkg := loadSource(t, "package main\nfunc main() {}\n")
+	if _, _, err := check.(analysis.TypedCheck).Check(pkg, nil); err == nil {
+		t.Error("expected an error for a missing path argument")
+	}
+}
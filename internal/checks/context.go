@@ -0,0 +1,76 @@
+package checks
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&contextFirstCheck{})
+}
+
+type contextFirstCheck struct{}
+
+func (c *contextFirstCheck) Name() string {
+	return "context_first"
+}
+
+func (c *contextFirstCheck) Description() string {
+	return "Checks that context.Context is the first parameter of functions taking one (args: optional func, which must take one)."
+}
+
+func (c *contextFirstCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *contextFirstCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	decls, err := funcDecls(pkg, args["func"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	var findings []analysis.Finding
+	for _, fn := range decls {
+		index := contextParam(pkg, fn)
+		switch {
+		case index > 0:
+			findings = append(findings, analysis.Finding{
+				Pos:     pkg.Position(fn.Name.Pos()),
+				Message: fmt.Sprintf("%s takes context.Context as parameter %d; it should come first", declName(fn), index+1),
+			})
+		case index < 0 && args["func"] != "":
+			findings = append(findings, analysis.Finding{
+				Pos:     pkg.Position(fn.Name.Pos()),
+				Message: fmt.Sprintf("%s should take a context.Context as its first parameter", declName(fn)),
+			})
+		}
+	}
+	ok, output := analysis.Report("context.Context comes first.", findings)
+	return ok, output, nil
+}
+
+// contextParam returns the index of fn's first context.Context parameter, or -1
+func contextParam(pkg *analysis.Package, fn *ast.FuncDecl) int {
+	obj, ok := pkg.Info.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return -1
+	}
+	params := obj.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if isContext(params.At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
@@ -0,0 +1,66 @@
+package checks
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&wrapsErrorsCheck{})
+}
+
+type wrapsErrorsCheck struct{}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func (c *wrapsErrorsCheck) Name() string {
+	return "wraps_errors"
+}
+
+func (c *wrapsErrorsCheck) Description() string {
+	return "Checks that fmt.Errorf wraps the errors it formats with %w (args: optional func)."
+}
+
+func (c *wrapsErrorsCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *wrapsErrorsCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	decls, err := funcDecls(pkg, args["func"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	var findings []analysis.Finding
+	for _, fn := range decls {
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+			if callee := pkg.Callee(call); callee == nil || analysis.FuncName(callee) != "fmt.Errorf" {
+				return true
+			}
+			format := pkg.Info.Types[call.Args[0]].Value
+			if format == nil || format.Kind() != constant.String || strings.Contains(constant.StringVal(format), "%w") {
+				return true
+			}
+			for _, arg := range call.Args[1:] {
+				if t := pkg.Info.TypeOf(arg); t != nil && types.Implements(t, errorType) {
+					findings = append(findings, analysis.Finding{
+						Pos:     pkg.Position(call.Pos()),
+						Message: "fmt.Errorf formats an error without %w, which breaks errors.Is and errors.As",
+					})
+					break
+				}
+			}
+			return true
+		})
+	}
+	ok, output := analysis.Report("Errors are wrapped with %w.", findings)
+	return ok, output, nil
+}
@@ -0,0 +1,77 @@
+package checks
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&usesGenericConstraintCheck{})
+}
+
+type usesGenericConstraintCheck struct{}
+
+func (c *usesGenericConstraintCheck) Name() string {
+	return "uses_generic_constraint"
+}
+
+func (c *usesGenericConstraintCheck) Description() string {
+	return "Checks that a generic function has a type parameter with the given constraint (args: constraint, optional func)."
+}
+
+func (c *usesGenericConstraintCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *usesGenericConstraintCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	if err := args.Require("constraint"); err != nil {
+		return false, "", err
+	}
+	decls, err := funcDecls(pkg, args["func"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	var seen []string
+	for _, fn := range decls {
+		obj, ok := pkg.Info.Defs[fn.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+		sig := obj.Type().(*types.Signature)
+		params := sig.TypeParams()
+		if sig.Recv() != nil {
+			params = sig.RecvTypeParams()
+		}
+		for i := 0; i < params.Len(); i++ {
+			constraint := constraintString(params.At(i).Constraint(), obj.Pkg())
+			if constraint == args["constraint"] {
+				return true, fmt.Sprintf("✅ %s constrains %s by %s.", declName(fn), params.At(i).Obj().Name(), constraint), nil
+			}
+			seen = append(seen, declName(fn)+"["+params.At(i).Obj().Name()+" "+constraint+"]")
+		}
+	}
+
+	where := "No generic function"
+	if args["func"] != "" {
+		where = args["func"]
+	}
+	output := fmt.Sprintf("❌ %s has no type parameter constrained by %s.", where, args["constraint"])
+	if len(seen) > 0 {
+		output += fmt.Sprintf(" Found: %v", seen)
+	}
+	return false, output, nil
+}
+
+// constraintString renders a constraint the way it is written in the source of local:
+// unqualified for local types, e.g. Number, and by package name otherwise, e.g. cmp.Ordered.
+func constraintString(t types.Type, local *types.Package) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == local {
+			return ""
+		}
+		return p.Name()
+	})
+}
@@ -0,0 +1,80 @@
+package checks
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&noNakedGoroutineCheck{})
+}
+
+type noNakedGoroutineCheck struct{}
+
+// syncPackages are the packages whose use counts as waiting for a goroutine
+var syncPackages = map[string]bool{
+	"sync":                             true,
+	"golang.org/x/sync/errgroup":       true,
+	"golang.org/x/sync/semaphore":      true,
+	"github.com/sourcegraph/conc":      true,
+	"github.com/sourcegraph/conc/pool": true,
+}
+
+func (c *noNakedGoroutineCheck) Name() string {
+	return "no_naked_goroutine"
+}
+
+func (c *noNakedGoroutineCheck) Description() string {
+	return "Checks that functions starting goroutines synchronize with them, through a channel or the sync package (args: optional func)."
+}
+
+func (c *noNakedGoroutineCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *noNakedGoroutineCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	decls, err := funcDecls(pkg, args["func"])
+	if err != nil {
+		return false, "❌ " + err.Error(), nil
+	}
+
+	var findings []analysis.Finding
+	for _, fn := range decls {
+		var starts []*ast.GoStmt
+		synced := false
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.GoStmt:
+				starts = append(starts, node)
+			case *ast.SendStmt, *ast.SelectStmt:
+				synced = true
+			case *ast.UnaryExpr:
+				synced = synced || node.Op == token.ARROW
+			case *ast.RangeStmt:
+				if t := pkg.Info.TypeOf(node.X); t != nil {
+					_, isChan := t.Underlying().(*types.Chan)
+					synced = synced || isChan
+				}
+			case *ast.Ident:
+				if obj := pkg.Info.Uses[node]; obj != nil && obj.Pkg() != nil {
+					synced = synced || syncPackages[obj.Pkg().Path()]
+				}
+			}
+			return true
+		})
+		if synced {
+			continue
+		}
+		for _, stmt := range starts {
+			findings = append(findings, analysis.Finding{
+				Pos:     pkg.Position(stmt.Pos()),
+				Message: declName(fn) + " starts a goroutine but never waits for it; use a sync.WaitGroup, errgroup or channel",
+			})
+		}
+	}
+	ok, output := analysis.Report("Every goroutine is synchronized.", findings)
+	return ok, output, nil
+}
@@ -0,0 +1,46 @@
+package checks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&forbidImportCheck{})
+}
+
+type forbidImportCheck struct{}
+
+func (c *forbidImportCheck) Name() string {
+	return "forbid_import"
+}
+
+func (c *forbidImportCheck) Description() string {
+	return "Checks that a package is not imported (args: path)."
+}
+
+func (c *forbidImportCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *forbidImportCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	if err := args.Require("path"); err != nil {
+		return false, "", err
+	}
+
+	var findings []analysis.Finding
+	for _, file := range pkg.Files {
+		for _, spec := range file.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); path == args["path"] {
+				findings = append(findings, analysis.Finding{
+					Pos:     pkg.Position(spec.Pos()),
+					Message: fmt.Sprintf("package %q must not be used in this exercise", path),
+				})
+			}
+		}
+	}
+	ok, output := analysis.Report(fmt.Sprintf("Package %q is not imported.", args["path"]), findings)
+	return ok, output, nil
+}
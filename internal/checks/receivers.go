@@ -0,0 +1,61 @@
+package checks
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+func init() {
+	analysis.Register(&pointerReceiverCheck{})
+}
+
+type pointerReceiverCheck struct{}
+
+func (c *pointerReceiverCheck) Name() string {
+	return "pointer_receiver"
+}
+
+func (c *pointerReceiverCheck) Description() string {
+	return "Checks that a method is declared on a pointer receiver (args: type, method)."
+}
+
+func (c *pointerReceiverCheck) Execute(filePath string) (bool, string, error) {
+	return analysis.ExecuteFile(c, filePath)
+}
+
+func (c *pointerReceiverCheck) Check(pkg *analysis.Package, args analysis.Args) (bool, string, error) {
+	if err := args.Require("type", "method"); err != nil {
+		return false, "", err
+	}
+	typeName, method := args["type"], args["method"]
+
+	for _, p := range pkg.Types {
+		obj, ok := p.Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return false, fmt.Sprintf("❌ %s is not a defined type.", typeName), nil
+		}
+		for i := 0; i < named.NumMethods(); i++ {
+			m := named.Method(i)
+			if m.Name() != method {
+				continue
+			}
+			if _, ok := m.Type().(*types.Signature).Recv().Type().(*types.Pointer); ok {
+				return true, fmt.Sprintf("✅ %s.%s has a pointer receiver.", typeName, method), nil
+			}
+			finding := analysis.Finding{
+				Pos:     pkg.Position(m.Pos()),
+				Message: fmt.Sprintf("%s.%s has a value receiver; use *%s so it can modify the receiver", typeName, method, typeName),
+			}
+			ok, output := analysis.Report("", []analysis.Finding{finding})
+			return ok, output, nil
+		}
+		return false, fmt.Sprintf("❌ %s has no method %s.", typeName, method), nil
+	}
+	return false, fmt.Sprintf("❌ Type %s not found.", typeName), nil
+}
@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+)
+
+// funcDecls returns the function declarations with a body in pkg. A non-empty name keeps
+// only that function, written as "F" or "Type.Method"; it is an error if there is none.
+func funcDecls(pkg *analysis.Package, name string) ([]*ast.FuncDecl, error) {
+	var decls []*ast.FuncDecl
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || (name != "" && declName(fn) != name) {
+				continue
+			}
+			decls = append(decls, fn)
+		}
+	}
+	if name != "" && len(decls) == 0 {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	return decls, nil
+}
+
+// declName returns fn's name, qualified with its receiver type for methods
+func declName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ = t.X
+	case *ast.IndexListExpr:
+		typ = t.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// isLoop reports whether n is a for or range statement
+func isLoop(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.ForStmt, *ast.RangeStmt:
+		return true
+	}
+	return false
+}
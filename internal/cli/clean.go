@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/stonecharioteer/goforgo/internal/deps"
 )
 
 var cleanCmd = &cobra.Command{
@@ -15,7 +16,8 @@ var cleanCmd = &cobra.Command{
 	Long: `Remove binaries, go.mod, and go.sum files created by running exercises.
 
 These files are generated automatically when exercises are compiled and
-can be safely removed at any time.
+can be safely removed at any time. The workspace go.mod and go.sum at the
+top of exercises/ are kept, since they pin third-party library versions.
 
 Examples:
   goforgo clean`,
@@ -47,6 +49,8 @@ func cleanArtifacts(cmd *cobra.Command, args []string) error {
 		shouldRemove := false
 
 		switch {
+		case deps.IsWorkspaceFile(exercisesDir, path):
+			// The workspace module from 'goforgo init' carries pinned dependencies; keep it
 		case name == "go.mod" || name == "go.sum":
 			shouldRemove = true
 		case !strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, ".toml"):
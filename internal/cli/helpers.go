@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/stonecharioteer/goforgo/internal/exercise"
 )
@@ -18,6 +19,15 @@ func loadExerciseManager() (*exercise.ExerciseManager, string, error) {
 	if err := em.LoadExercises(); err != nil {
 		return nil, cwd, err
 	}
+	printWarnings(em)
 
 	return em, cwd, nil
 }
+
+// printWarnings reports what loading the exercises skipped over, on stderr so it
+// doesn't mix with machine-readable output
+func printWarnings(em *exercise.ExerciseManager) {
+	for _, warning := range em.Warnings() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+}
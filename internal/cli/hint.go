@@ -63,6 +63,9 @@ func showHint(cmd *cobra.Command, args []string) error {
 
 	// Show the hint
 	fmt.Printf("💡 Hint: %s\n\n", ex.GetHint())
+	if err := em.RecordHintViewed(ex.Info.Name); err != nil {
+		fmt.Printf("⚠️  Warning: Failed to save progress: %v\n", err)
+	}
 
 	fmt.Printf("🔧 Edit %s and run 'goforgo run %s' to test your solution.\n", ex.FilePath, ex.Info.Name)
 
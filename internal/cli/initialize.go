@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	goforgo "github.com/stonecharioteer/goforgo"
+	"github.com/stonecharioteer/goforgo/internal/deps"
 	"github.com/stonecharioteer/goforgo/internal/exercise"
 )
 
@@ -55,7 +56,32 @@ completed_exercises = []
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
 
-	return copyExerciseFiles(baseDir)
+	if err := copyExerciseFiles(baseDir); err != nil {
+		return err
+	}
+
+	return setupModules(baseDir)
+}
+
+// setupModules writes the workspace modules exercises and solutions build in, and
+// unpacks the embedded module bundle when this binary carries one.
+func setupModules(baseDir string) error {
+	fmt.Println("📦 Writing workspace modules...")
+
+	for _, dir := range []string{"exercises", "solutions"} {
+		if err := deps.WriteWorkspace(filepath.Join(baseDir, dir), deps.DefaultGoVersion); err != nil {
+			return fmt.Errorf("failed to write %s module: %w", dir, err)
+		}
+	}
+
+	if deps.HasBundle() {
+		fmt.Println("📦 Unpacking bundled modules for offline use...")
+		if err := deps.ExtractBundle(baseDir); err != nil {
+			return fmt.Errorf("failed to unpack module bundle: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func copyExerciseFiles(baseDir string) error {
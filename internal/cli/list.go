@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 var (
 	listAll      bool
 	listCategory string
+	listTag      string
 	listOneLine  bool
 )
 
@@ -27,6 +29,7 @@ Examples:
   goforgo list                    # Show incomplete exercises
   goforgo list --all              # Show all exercises
   goforgo list --category basics  # Show exercises in 'basics' category
+  goforgo list --tag concurrency  # Show exercises tagged 'concurrency'
   goforgo list --oneline          # One exercise per line for shell processing`,
 	RunE: listExercises,
 }
@@ -56,6 +59,10 @@ func listExercises(cmd *cobra.Command, args []string) error {
 			continue // Skip exercises not in the specified category
 		}
 
+		if listTag != "" && !ex.HasTag(listTag) {
+			continue // Skip exercises without the specified tag
+		}
+
 		filteredExercises = append(filteredExercises, ex)
 	}
 
@@ -79,6 +86,10 @@ func listExercises(cmd *cobra.Command, args []string) error {
 			status := "incomplete"
 			if ex.Completed {
 				status = "complete"
+			} else if !ex.IsAvailable() {
+				status = "unavailable"
+			} else if !ex.IsUnlocked() {
+				status = "locked"
 			}
 
 			// Simple difficulty without stars
@@ -135,6 +146,10 @@ func listExercises(cmd *cobra.Command, args []string) error {
 	completedCount, totalCount, percentage := em.GetProgressStats()
 	fmt.Printf("GoForGo Exercises - Progress: %d/%d (%.1f%% complete)\n",
 		completedCount, totalCount, percentage)
+	track := em.ActiveTrack()
+	if track != nil {
+		fmt.Printf("🧭 Track: %s (%s)\n", track.Name, track.Title)
+	}
 	fmt.Println(strings.Repeat("═", 60))
 
 	if len(filteredExercises) == 0 {
@@ -152,12 +167,20 @@ func listExercises(cmd *cobra.Command, args []string) error {
 		categories[ex.Info.Category] = append(categories[ex.Info.Category], ex)
 	}
 
-	// Sort categories for consistent display order
+	// Sort categories for consistent display order; a track keeps its own order
 	sortedCategories := make([]string, 0, len(categories))
-	for category := range categories {
-		sortedCategories = append(sortedCategories, category)
+	if track != nil {
+		for _, ex := range filteredExercises {
+			if !slices.Contains(sortedCategories, ex.Info.Category) {
+				sortedCategories = append(sortedCategories, ex.Info.Category)
+			}
+		}
+	} else {
+		for category := range categories {
+			sortedCategories = append(sortedCategories, category)
+		}
+		sort.Strings(sortedCategories)
 	}
-	sort.Strings(sortedCategories)
 
 	// Display exercises grouped by category
 	for _, category := range sortedCategories {
@@ -169,17 +192,31 @@ func listExercises(cmd *cobra.Command, args []string) error {
 			status := "❌"
 			if ex.Completed {
 				status = "✅"
+			} else if !ex.IsAvailable() {
+				status = "🚫"
+			} else if !ex.IsUnlocked() {
+				status = "🔒"
 			}
 
 			// Format difficulty stars
 			difficultyStr := ex.GetDifficultyString()
 
-			fmt.Printf("  %s %-20s %s\n", status, ex.Info.Name, difficultyStr)
+			optional := ""
+			if em.IsOptional(ex) {
+				optional = "  (optional)"
+			}
+
+			fmt.Printf("  %s %-20s %s%s\n", status, ex.Info.Name, difficultyStr, optional)
 			fmt.Printf("      %s\n", ex.Description.Title)
 
 			if ex.Info.EstimatedTime != "" {
 				fmt.Printf("      ⏱️  Estimated time: %s\n", ex.Info.EstimatedTime)
 			}
+			if !ex.IsAvailable() {
+				fmt.Printf("      🚫 Unavailable: %s\n", ex.Unavailable)
+			} else if !ex.IsUnlocked() {
+				fmt.Printf("      🔒 Locked: run 'goforgo path %s' to see what unlocks it\n", ex.Info.Name)
+			}
 			fmt.Println()
 		}
 	}
@@ -202,5 +239,6 @@ func init() {
 	// Add flags
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Show all exercises including completed ones")
 	listCmd.Flags().StringVar(&listCategory, "category", "", "Filter exercises by category")
+	listCmd.Flags().StringVar(&listTag, "tag", "", "Filter exercises by metadata tag")
 	listCmd.Flags().BoolVar(&listOneLine, "oneline", false, "Output one exercise per line for shell processing (format: category_number|category_name|exercise_number|exercise_name|difficulty|status|title|time)")
 }
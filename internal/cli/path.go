@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// pathCmd represents the path command
+var pathCmd = &cobra.Command{
+	Use:   "path <target>",
+	Short: "Show the exercises needed to reach a topic",
+	Long: `Show the shortest learning path to a target: the incomplete exercises it needs,
+following prerequisites, in the order to do them.
+
+The target can be an exercise path, an exercise name, a category (with or
+without its number prefix) or a metadata tag.
+
+Examples:
+  goforgo path kafka                 # Everything needed to finish the Kafka category
+  goforgo path 42_kafka/streams      # Everything needed to do one exercise
+  goforgo path concurrency           # Everything tagged 'concurrency'`,
+	Args: cobra.ExactArgs(1),
+	RunE: showPath,
+}
+
+func showPath(cmd *cobra.Command, args []string) error {
+	em, _, err := loadExerciseManager()
+	if err != nil {
+		return err
+	}
+
+	targets, err := em.FindTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	path := em.PathTo(targets)
+	if len(path) == 0 {
+		fmt.Printf("🎉 You've already completed everything needed for '%s'!\n", args[0])
+		return nil
+	}
+
+	var total time.Duration
+	for _, ex := range path {
+		if d, err := time.ParseDuration(ex.Info.EstimatedTime); err == nil {
+			total += d
+		}
+	}
+
+	fmt.Printf("🧭 Path to '%s': %d exercises", args[0], len(path))
+	if total > 0 {
+		fmt.Printf(", about %s", strings.TrimSuffix(total.String(), "0s"))
+	}
+	fmt.Println()
+
+	for i, ex := range path {
+		status := "🔓"
+		if !ex.IsAvailable() {
+			status = "🚫"
+		} else if !ex.IsUnlocked() {
+			status = "🔒"
+		}
+		fmt.Printf("  %2d. %s %s/%s: %s\n", i+1, status, ex.Info.Category, ex.Info.Name, ex.Description.Title)
+	}
+
+	fmt.Printf("\n🎯 Start with 'goforgo run %s'.\n", path[0].Info.Name)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(pathCmd)
+}
@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stonecharioteer/goforgo"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/profile"
+)
+
+var (
+	exportSources bool
+	importReplace bool
+	importForce   bool
+	importNoFiles bool
+	serveAddr     string
+	serveDir      string
+	serveStore    string
+)
+
+// progressCmd represents the progress command
+var progressCmd = &cobra.Command{
+	Use:   "progress",
+	Short: "Export, import or serve progress",
+	Long: `Move your progress to another machine, or merge it back after a reinstall.
+
+An export is a single zip archive holding your progress file, with the history
+of every exercise. With --sources it also holds your changes to exercise files,
+as diffs against the exercises shipped with goforgo.
+
+Importing merges the archive's progress into the progress here, keeping the
+larger of each count, so importing the same archive twice is harmless. Exercises
+renamed in a newer goforgo are matched through the aliases in their TOML files,
+and exercises that no longer exist are reported and skipped.
+
+Progress is kept in .goforgo-progress.toml unless GOFORGO_PROGRESS_STORE says
+otherwise: "sqlite" keeps it in .goforgo-progress.db, "sqlite:<path>" in another
+SQLite database, and an http(s) URL on a progress server, such as one started
+with 'goforgo progress serve'.
+
+Examples:
+  goforgo progress export                        # Write goforgo-profile-<date>.zip
+  goforgo progress export --sources me.zip       # Include your exercise changes
+  goforgo progress import me.zip                 # Merge progress and restore changes
+  goforgo progress import --replace me.zip       # Replace the progress here instead
+  goforgo progress serve --dir class             # Keep a classroom's progress
+  goforgo progress token alice bob               # Print learners' server tokens`,
+}
+
+var progressExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write your progress to an archive",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		em, _, err := loadExerciseManager()
+		if err != nil {
+			return err
+		}
+		pristine, err := fs.Sub(goforgo.Content, "exercises")
+		if err != nil {
+			return err
+		}
+
+		output := fmt.Sprintf("goforgo-profile-%s.zip", time.Now().Format("20060102"))
+		if len(args) == 1 {
+			output = args[0]
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", output, err)
+		}
+
+		manifest, err := profile.Export(file, em, pristine, profile.ExportOptions{Version: version, Sources: exportSources})
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(output)
+			return err
+		}
+
+		fmt.Printf("📦 Exported %d completed exercises to %s\n", manifest.Completed, output)
+		if exportSources {
+			fmt.Printf("   Including changes to %d exercise files\n", len(manifest.Sources))
+		}
+		return nil
+	},
+}
+
+var progressImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add the progress from an archive to this workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		em, _, err := loadExerciseManager()
+		if err != nil {
+			return err
+		}
+		pristine, err := fs.Sub(goforgo.Content, "exercises")
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", args[0], err)
+		}
+		defer func() { _ = file.Close() }()
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+
+		report, err := profile.Import(file, info.Size(), em, pristine, profile.ImportOptions{
+			Replace:     importReplace,
+			Force:       importForce,
+			SkipSources: importNoFiles,
+		})
+		if err != nil {
+			return err
+		}
+
+		completed, total, percentage := em.GetProgressStats()
+		fmt.Printf("📥 Imported progress exported by goforgo %s on %s\n", report.Manifest.GoforgoVersion, report.Manifest.ExportedAt.Format("2006-01-02"))
+		fmt.Printf("   Progress: %d/%d (%.1f%% complete)\n", completed, total, percentage)
+
+		renamed := make([]string, 0, len(report.Progress.Renamed))
+		for old := range report.Progress.Renamed {
+			renamed = append(renamed, old)
+		}
+		sort.Strings(renamed)
+		for _, old := range renamed {
+			fmt.Printf("   ↪️  %s is now %s\n", old, report.Progress.Renamed[old])
+		}
+		for _, name := range report.Progress.Removed {
+			fmt.Printf("   🗑️  %s no longer exists; its progress was skipped\n", name)
+		}
+
+		if len(report.Applied) > 0 {
+			fmt.Printf("   Restored %d exercise files\n", len(report.Applied))
+		}
+		for _, skipped := range report.Skipped {
+			fmt.Printf("   ⚠️  %s: %s\n", skipped.File, skipped.Reason)
+		}
+		return nil
+	},
+}
+
+var progressServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a progress server that keeps many learners' progress",
+	Long: `Run a progress server, so a classroom can keep everyone's progress in one place.
+
+Each learner's progress lives at /progress/<learner>, in a file of its own under
+--dir. Learners point goforgo at it with:
+
+  export GOFORGO_PROGRESS_STORE=http://<host>:8787/progress/<learner>
+
+When GOFORGO_PROGRESS_SECRET is set, every learner needs a token of their own,
+derived from the secret, which 'goforgo progress token <learner>' prints. They
+set it as GOFORGO_PROGRESS_TOKEN, and it only opens their own progress.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveStore != "toml" && serveStore != "sqlite" {
+			return fmt.Errorf("unknown store %q; use toml or sqlite", serveStore)
+		}
+		if err := os.MkdirAll(serveDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", serveDir, err)
+		}
+		open := func(learner string) (exercise.ProgressStore, error) {
+			if serveStore == "sqlite" {
+				return exercise.NewSQLiteStore(filepath.Join(serveDir, learner+".db")), nil
+			}
+			return exercise.NewTOMLStore(filepath.Join(serveDir, learner+".toml")), nil
+		}
+
+		secret := os.Getenv(exercise.ProgressSecretEnv)
+		fmt.Printf("📡 Serving progress from %s on %s\n", serveDir, serveAddr)
+		if secret == "" {
+			fmt.Printf("⚠️  %s isn't set, so anyone who can reach the server can change any learner's progress\n", exercise.ProgressSecretEnv)
+		}
+		server := &http.Server{
+			Addr:              serveAddr,
+			Handler:           exercise.NewProgressHandler(open, secret),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		return server.ListenAndServe()
+	},
+}
+
+var progressTokenCmd = &cobra.Command{
+	Use:   "token <learner>...",
+	Short: "Print the progress server tokens of learners",
+	Long: `Print each learner's token for a progress server run with the same
+GOFORGO_PROGRESS_SECRET. Hand every learner their own token; they set it as
+GOFORGO_PROGRESS_TOKEN.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secret := os.Getenv(exercise.ProgressSecretEnv)
+		if secret == "" {
+			return fmt.Errorf("%s isn't set; use the secret the progress server runs with", exercise.ProgressSecretEnv)
+		}
+		for _, learner := range args {
+			fmt.Printf("%s\t%s\n", learner, exercise.LearnerToken(secret, learner))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(progressCmd)
+	progressCmd.AddCommand(progressExportCmd, progressImportCmd, progressServeCmd, progressTokenCmd)
+	progressExportCmd.Flags().BoolVar(&exportSources, "sources", false, "Include your changes to exercise files")
+	progressImportCmd.Flags().BoolVar(&importReplace, "replace", false, "Replace the progress here instead of adding to it")
+	progressImportCmd.Flags().BoolVar(&importForce, "force", false, "Overwrite exercise files you've also changed here")
+	progressImportCmd.Flags().BoolVar(&importNoFiles, "no-sources", false, "Import progress only, leaving exercise files alone")
+	progressServeCmd.Flags().StringVar(&serveAddr, "addr", ":8787", "Address to listen on")
+	progressServeCmd.Flags().StringVar(&serveDir, "dir", "goforgo-progress", "Directory keeping the learners' progress")
+	progressServeCmd.Flags().StringVar(&serveStore, "store", "toml", "How each learner's progress is kept: toml or sqlite")
+}
@@ -4,15 +4,29 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/spf13/cobra"
+	goforgo "github.com/stonecharioteer/goforgo"
 )
 
 var resetCmd = &cobra.Command{
-	Use:   "reset",
+	Use:   "reset [exercise_name]",
 	Short: "Reset your progress and all exercises to a clean state",
-	Long:  `Reset your progress and all exercises to a clean state. This will delete all your work and re-initialize the exercises directory.`,
+	Long: `Reset your progress and all exercises to a clean state. This will delete all your work and re-initialize the exercises directory.
+
+Pass an exercise name to restore only that exercise. Multi-file exercises are
+restored as a unit: every file, or the whole package directory, is replaced.
+
+Examples:
+  goforgo reset                  # Reset everything
+  goforgo reset project_layout   # Restore one exercise`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			return resetSingleExercise(args[0])
+		}
+
 		cwd, err := GetWorkingDirectory()
 		if err != nil {
 			return fmt.Errorf("failed to get working directory: %w", err)
@@ -46,6 +60,59 @@ var resetCmd = &cobra.Command{
 	},
 }
 
+// resetSingleExercise restores one exercise's files from the embedded originals and clears its progress
+func resetSingleExercise(name string) error {
+	em, _, err := loadExerciseManager()
+	if err != nil {
+		return err
+	}
+
+	ex, err := em.GetExerciseByName(name)
+	if err != nil {
+		return err
+	}
+
+	if ex.IsPackage() {
+		// Replace the whole package so files the learner added are dropped too.
+		if err := os.RemoveAll(ex.Dir); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", ex.Dir, err)
+		}
+		if err := copyEmbeddedDir(embeddedExercisePath(em.ExercisesPath, ex.Dir), ex.Dir); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", ex.Dir, err)
+		}
+	} else {
+		files := append([]string{}, ex.Files...)
+		if ex.TestFilePath != "" && !slices.Contains(files, ex.TestFilePath) {
+			files = append(files, ex.TestFilePath)
+		}
+		for _, file := range files {
+			content, err := goforgo.Content.ReadFile(embeddedExercisePath(em.ExercisesPath, file))
+			if err != nil {
+				return fmt.Errorf("no pristine copy of %s: %w", file, err)
+			}
+			if err := os.WriteFile(file, content, 0644); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", file, err)
+			}
+		}
+	}
+
+	if err := em.UnmarkExerciseCompleted(ex.Info.Name); err != nil {
+		return fmt.Errorf("failed to update progress: %w", err)
+	}
+
+	fmt.Printf("✅ Exercise '%s' has been reset.\n", ex.Info.Name)
+	return nil
+}
+
+// embeddedExercisePath maps a path under the local exercises directory to its embedded counterpart
+func embeddedExercisePath(exercisesPath, path string) string {
+	rel, err := filepath.Rel(exercisesPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(filepath.Join("exercises", rel))
+}
+
 func init() {
 	rootCmd.AddCommand(resetCmd)
 }
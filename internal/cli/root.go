@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/stonecharioteer/goforgo/internal/toolchain"
 )
 
 var (
@@ -31,6 +32,11 @@ Similar to Rustlings for Rust, GoForGo helps you learn Go by fixing broken
 code exercises, with automatic compilation and testing to guide your progress.`,
 	Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Detect the Go toolchain once up front; exercise loading and the runner reuse the result.
+		if _, err := toolchain.Detect(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  %v\n   Install Go from https://go.dev/dl/ to run exercises.\n", err)
+		}
+
 		if noUpdateCheck || cmd.Name() == "self-update" {
 			return
 		}
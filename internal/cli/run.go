@@ -91,6 +91,10 @@ func runExercise(cmd *cobra.Command, args []string) error {
 
 	success := result.Success
 
+	if err := em.RecordRun(ex.Info.Name, success, result.FailureKind); err != nil {
+		fmt.Printf("⚠️  Warning: Failed to save progress: %v\n", err)
+	}
+
 	if success {
 		// Mark the exercise as completed
 		if err := em.MarkExerciseCompleted(ex.Info.Name); err != nil {
@@ -108,8 +112,6 @@ func runExercise(cmd *cobra.Command, args []string) error {
 			fmt.Println("\n🏆 All exercises completed! You're a Go expert now!")
 		}
 	} else {
-		// Increment attempt count for better hint selection
-		ex.Attempts++
 		fmt.Printf("💡 Hint: %s\n", ex.GetHint())
 		fmt.Printf("\n🔧 Edit the file and run 'goforgo run %s' again, or use 'goforgo' for watch mode.\n", ex.Info.Name)
 	}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/stats"
+)
+
+var (
+	statsFormat string
+	statsDays   int
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show learning analytics from your progress history",
+	Long: `Show learning analytics from your progress history: completion by category,
+streaks, average attempts and time per difficulty level, the exercises that
+failed most, estimated versus actual time, and a sparkline of daily activity.
+
+Statistics cover the active track, or the whole course when no track is active.
+JSON and CSV output are meant for aggregating progress across learners; CSV has
+one row per exercise.
+
+Examples:
+  goforgo stats                        # Human-readable summary
+  goforgo stats --days 90              # Sparkline over the last 90 days
+  goforgo stats --format json          # Full report as JSON
+  goforgo stats --format csv > me.csv  # Per-exercise history as CSV`,
+	Args: cobra.NoArgs,
+	RunE: showStats,
+}
+
+func showStats(cmd *cobra.Command, args []string) error {
+	if statsDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	if !stats.ValidFormat(statsFormat) {
+		return fmt.Errorf("unknown format '%s' (use text, json or csv)", statsFormat)
+	}
+
+	cwd, err := GetWorkingDirectory()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	em := exercise.NewExerciseManager(cwd)
+	em.Quiet = statsFormat != stats.FormatText
+	if err := em.LoadExercises(); err != nil {
+		return err
+	}
+	printWarnings(em)
+
+	report := stats.Build(em, time.Now(), statsDays)
+	return stats.Write(os.Stdout, report, statsFormat)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsFormat, "format", stats.FormatText, "Output format: text, json or csv")
+	statsCmd.Flags().IntVar(&statsDays, "days", 30, "Number of days of daily activity to include")
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// trackCmd represents the track command
+var trackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Choose a curated track of exercises",
+	Long: `Tracks are named subsets of the course, such as an onboarding path for one
+team, with their own exercise order and optional exercises.
+
+While a track is active, the TUI, 'list', 'sync', progress percentages and the
+next exercise are all scoped to it. Built-in tracks ship with goforgo; add your
+own as TOML files in the tracks/ directory of your workspace.
+
+Examples:
+  goforgo track list           # Show the tracks and your progress in each
+  goforgo track use backend    # Scope goforgo to the 'backend' track
+  goforgo track clear          # Go back to the whole course`,
+}
+
+var trackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available tracks with your progress in each",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		em, _, err := loadExerciseManager()
+		if err != nil {
+			return err
+		}
+
+		tracks, err := em.LoadTracks()
+		if err != nil {
+			return err
+		}
+		if len(tracks) == 0 {
+			fmt.Println("No tracks found.")
+			return nil
+		}
+
+		active := ""
+		if track := em.ActiveTrack(); track != nil {
+			active = track.Name
+		}
+		for _, track := range tracks {
+			marker := "  "
+			if track.Name == active {
+				marker = "▶ "
+			}
+			completed, total, err := em.TrackProgress(track)
+			if err != nil {
+				fmt.Printf("%s%-12s ⚠️  %v\n", marker, track.Name, err)
+				continue
+			}
+			percentage := 0.0
+			if total > 0 {
+				percentage = float64(completed) / float64(total) * 100
+			}
+			fmt.Printf("%s%-12s %3d/%-3d (%5.1f%%)  %s\n", marker, track.Name, completed, total, percentage, track.Title)
+			if track.Description != "" {
+				fmt.Printf("  %-12s %s\n", "", track.Description)
+			}
+		}
+		return nil
+	},
+}
+
+var trackUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Scope goforgo to a track",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		em, _, err := loadExerciseManager()
+		if err != nil {
+			return err
+		}
+		if err := em.UseTrack(args[0]); err != nil {
+			return err
+		}
+
+		track := em.ActiveTrack()
+		completed, total, percentage := em.GetProgressStats()
+		fmt.Printf("🧭 Now on track '%s': %s\n", track.Name, track.Title)
+		fmt.Printf("   Progress: %d/%d (%.1f%% complete)\n", completed, total, percentage)
+		if next := em.GetNextExercise(); next != nil {
+			fmt.Printf("   Next exercise: %s (%s)\n", next.Info.Name, next.Description.Title)
+		}
+		return nil
+	},
+}
+
+var trackClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Go back to the whole course",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		em, _, err := loadExerciseManager()
+		if err != nil {
+			return err
+		}
+		if err := em.UseTrack(""); err != nil {
+			return err
+		}
+		fmt.Println("🧭 Track cleared: showing the whole course.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trackCmd)
+	trackCmd.AddCommand(trackListCmd, trackUseCmd, trackClearCmd)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	goforgo "github.com/stonecharioteer/goforgo"
+	"github.com/stonecharioteer/goforgo/internal/deps"
 	"github.com/stonecharioteer/goforgo/internal/exercise"
 )
 
@@ -138,6 +139,11 @@ func updateExerciseFiles(baseDir string, completedExercises map[string]bool) err
 		fmt.Printf("  🗑️  Removed %d stale files\n", removed)
 	}
 
+	// Refresh the workspace modules so they match this binary's dependency versions
+	if err := setupModules(baseDir); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -188,7 +194,7 @@ func removeStaleFiles(baseDir string) (int, error) {
 			if relErr != nil {
 				return relErr
 			}
-			if !embeddedPaths[relPath] {
+			if !embeddedPaths[relPath] && !deps.IsWorkspaceFile(diskDir, path) {
 				fmt.Printf("  🗑️  Removing stale file: %s/%s\n", dir, relPath)
 				if rmErr := os.Remove(path); rmErr != nil {
 					return rmErr
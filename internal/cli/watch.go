@@ -31,6 +31,7 @@ Use keyboard shortcuts:
   r - manually run current exercise
   n - move to next exercise
   h - show hint
+  e - open the exercise in $EDITOR at the first TODO
   q - quit`,
 	RunE: startWatchMode,
 }
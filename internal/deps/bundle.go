@@ -0,0 +1,97 @@
+package deps
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BundleDir is where an embedded module bundle is unpacked, relative to the
+// goforgo working directory. It is laid out as a GOPROXY file tree.
+const BundleDir = ".goforgo/modcache"
+
+// ErrNoBundle is returned when the binary was built without an embedded module bundle
+var ErrNoBundle = errors.New("this goforgo build has no embedded module bundle (rebuild with -tags goforgo_bundle)")
+
+// HasBundle reports whether the binary carries an embedded module bundle
+func HasBundle() bool {
+	return len(bundle) > 0
+}
+
+// ExtractBundle unpacks the embedded module bundle into baseDir/BundleDir
+func ExtractBundle(baseDir string) error {
+	if !HasBundle() {
+		return ErrNoBundle
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return fmt.Errorf("failed to open module bundle: %w", err)
+	}
+
+	dest := filepath.Join(baseDir, BundleDir)
+	if err := os.RemoveAll(dest); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", dest, err)
+	}
+
+	for _, file := range reader.File {
+		target := filepath.Join(dest, filepath.FromSlash(file.Name))
+		if !strings.HasPrefix(target, dest+string(filepath.Separator)) {
+			return fmt.Errorf("module bundle entry escapes destination: %s", file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if err := extractFile(file, target); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func extractFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// GoEnv returns the environment overrides that point the go command at an
+// unpacked bundle, or nil when baseDir has none. Modules are then resolved
+// from disk and never from the network.
+func GoEnv(baseDir string) []string {
+	dir, err := filepath.Abs(filepath.Join(baseDir, BundleDir))
+	if err != nil {
+		return nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	return []string{
+		"GOPROXY=file://" + filepath.ToSlash(dir),
+		"GOSUMDB=off", // go.sum is written alongside go.mod, so nothing needs the checksum database
+		"GOTOOLCHAIN=local",
+	}
+}
@@ -0,0 +1,11 @@
+//go:build goforgo_bundle
+
+package deps
+
+import _ "embed"
+
+// bundle is a zip of a GOPROXY file tree holding every module in goforgo's
+// go.sum, produced by scripts/build_bundle.sh.
+//
+//go:embed modcache.zip
+var bundle []byte
@@ -0,0 +1,6 @@
+//go:build !goforgo_bundle
+
+package deps
+
+// bundle is empty in regular builds; see scripts/build_bundle.sh
+var bundle []byte
@@ -0,0 +1,222 @@
+// Package deps lays down the Go module exercises are built in, pinned to the
+// same dependency versions goforgo itself was built with, so third-party
+// library exercises resolve without guessing versions.
+package deps
+
+import (
+	"bufio"
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	goforgo "github.com/stonecharioteer/goforgo"
+)
+
+// ModulePath is the module path of the generated workspace modules. Package
+// exercises import their subpackages as goforgo/<category>/<exercise>/...
+const ModulePath = "goforgo"
+
+// DefaultGoVersion is the go directive written to generated go.mod files
+const DefaultGoVersion = "1.24"
+
+// Requirement is a single module the workspace requires
+type Requirement struct {
+	Path     string
+	Version  string
+	Sum      string // h1: hash of the module zip, when known from build info
+	Indirect bool
+}
+
+// Requirements returns the exact requirements for the workspace module.
+// The embedded go.mod lists every library the exercises import; versions of
+// modules linked into the running binary are taken from its build info.
+func Requirements() ([]Requirement, error) {
+	data, err := goforgo.Modules.ReadFile("go.mod")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded go.mod: %w", err)
+	}
+
+	reqs := parseRequires(string(data))
+	if info, ok := debug.ReadBuildInfo(); ok {
+		reqs = overlayBuildInfo(reqs, info.Deps)
+	}
+	return reqs, nil
+}
+
+// parseRequires extracts require directives from go.mod content, in both the
+// single-line and block forms.
+func parseRequires(gomod string) []Requirement {
+	var reqs []Requirement
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(gomod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inBlock:
+			continue
+		}
+
+		indirect := strings.HasSuffix(line, "// indirect")
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		reqs = append(reqs, Requirement{Path: fields[0], Version: fields[1], Indirect: indirect})
+	}
+
+	return reqs
+}
+
+// parseGoDirective returns the version from a go.mod's go directive
+func parseGoDirective(gomod string) string {
+	for _, line := range strings.Split(gomod, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// overlayBuildInfo pins requirements to the versions linked into the binary
+func overlayBuildInfo(reqs []Requirement, modules []*debug.Module) []Requirement {
+	index := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		index[req.Path] = i
+	}
+
+	for _, mod := range modules {
+		if mod.Replace != nil {
+			mod = mod.Replace
+		}
+		// Local replacements have no version and cannot be fetched by the learner's toolchain.
+		if mod.Version == "" || mod.Version == "(devel)" {
+			continue
+		}
+
+		if i, ok := index[mod.Path]; ok {
+			reqs[i].Version = mod.Version
+			reqs[i].Sum = mod.Sum
+			continue
+		}
+		index[mod.Path] = len(reqs)
+		reqs = append(reqs, Requirement{Path: mod.Path, Version: mod.Version, Sum: mod.Sum, Indirect: true})
+	}
+
+	return reqs
+}
+
+// GoMod renders a go.mod for the workspace module
+func GoMod(goVersion string, reqs []Requirement) string {
+	var direct, indirect []Requirement
+	for _, req := range reqs {
+		if req.Indirect {
+			indirect = append(indirect, req)
+		} else {
+			direct = append(direct, req)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("// Generated by goforgo. Rerun 'goforgo update' to refresh.\n\n")
+	fmt.Fprintf(&b, "module %s\n\ngo %s\n", ModulePath, goVersion)
+	writeRequireBlock(&b, direct, "")
+	writeRequireBlock(&b, indirect, " // indirect")
+	return b.String()
+}
+
+func writeRequireBlock(b *strings.Builder, reqs []Requirement, suffix string) {
+	if len(reqs) == 0 {
+		return
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].Path < reqs[j].Path })
+
+	b.WriteString("\nrequire (\n")
+	for _, req := range reqs {
+		fmt.Fprintf(b, "\t%s %s%s\n", req.Path, req.Version, suffix)
+	}
+	b.WriteString(")\n")
+}
+
+// GoSum returns the embedded go.sum, extended with any module hashes from
+// build info that it doesn't already contain.
+func GoSum(reqs []Requirement) (string, error) {
+	data, err := goforgo.Modules.ReadFile("go.sum")
+	if err != nil {
+		return "", fmt.Errorf("failed to read embedded go.sum: %w", err)
+	}
+
+	sum := string(data)
+	known := make(map[string]bool)
+	for _, line := range strings.Split(sum, "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			known[fields[0]+" "+fields[1]] = true
+		}
+	}
+
+	var extra []string
+	for _, req := range reqs {
+		if req.Sum != "" && !known[req.Path+" "+req.Version] {
+			extra = append(extra, fmt.Sprintf("%s %s %s", req.Path, req.Version, req.Sum))
+		}
+	}
+	sort.Strings(extra)
+
+	if len(extra) > 0 {
+		if !strings.HasSuffix(sum, "\n") {
+			sum += "\n"
+		}
+		sum += strings.Join(extra, "\n") + "\n"
+	}
+	return sum, nil
+}
+
+// WriteWorkspace writes go.mod and go.sum into dir, replacing any existing ones.
+// The go directive is raised to goforgo's own when the pinned dependencies need it.
+func WriteWorkspace(dir, goVersion string) error {
+	reqs, err := Requirements()
+	if err != nil {
+		return err
+	}
+	if data, err := goforgo.Modules.ReadFile("go.mod"); err == nil {
+		if own := parseGoDirective(string(data)); version.Compare("go"+own, "go"+goVersion) > 0 {
+			goVersion = own
+		}
+	}
+	sum, err := GoSum(reqs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(GoMod(goVersion, reqs)), 0644); err != nil {
+		return fmt.Errorf("failed to write go.mod: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.sum"), []byte(sum), 0644); err != nil {
+		return fmt.Errorf("failed to write go.sum: %w", err)
+	}
+	return nil
+}
+
+// IsWorkspaceFile reports whether path is a go.mod or go.sum written by WriteWorkspace
+// at the root of one of the workspace directories.
+func IsWorkspaceFile(root, path string) bool {
+	name := filepath.Base(path)
+	return (name == "go.mod" || name == "go.sum") && filepath.Dir(path) == filepath.Clean(root)
+}
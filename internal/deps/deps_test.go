@@ -0,0 +1,108 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestParseRequires(t *testing.T) {
+	gomod := `module example.com/m
+
+go 1.24.0
+
+require github.com/single/line v1.0.0
+
+require (
+	github.com/direct/dep v1.2.3
+	github.com/indirect/dep v0.1.0 // indirect
+)
+`
+	reqs := parseRequires(gomod)
+	if len(reqs) != 3 {
+		t.Fatalf("expected 3 requirements, got %d: %+v", len(reqs), reqs)
+	}
+	if reqs[0].Path != "github.com/single/line" || reqs[0].Version != "v1.0.0" {
+		t.Errorf("unexpected single-line requirement: %+v", reqs[0])
+	}
+	if reqs[1].Indirect || !reqs[2].Indirect {
+		t.Errorf("indirect markers not parsed: %+v", reqs)
+	}
+	if v := parseGoDirective(gomod); v != "1.24.0" {
+		t.Errorf("expected go directive 1.24.0, got %q", v)
+	}
+}
+
+func TestOverlayBuildInfo(t *testing.T) {
+	reqs := []Requirement{{Path: "github.com/a/a", Version: "v1.0.0"}}
+	modules := []*debug.Module{
+		{Path: "github.com/a/a", Version: "v1.1.0", Sum: "h1:aaa="},
+		{Path: "github.com/b/b", Version: "v2.0.0", Sum: "h1:bbb="},
+		{Path: "github.com/c/c", Version: "v1.0.0", Replace: &debug.Module{Path: "../c", Version: ""}},
+	}
+
+	reqs = overlayBuildInfo(reqs, modules)
+	if len(reqs) != 2 {
+		t.Fatalf("expected local replacement to be skipped, got %+v", reqs)
+	}
+	if reqs[0].Version != "v1.1.0" || reqs[0].Sum != "h1:aaa=" {
+		t.Errorf("expected build info version to win, got %+v", reqs[0])
+	}
+	if !reqs[1].Indirect || reqs[1].Version != "v2.0.0" {
+		t.Errorf("expected linked-only module added as indirect, got %+v", reqs[1])
+	}
+
+	sum, err := GoSum(reqs)
+	if err != nil {
+		t.Fatalf("GoSum returned error: %v", err)
+	}
+	if !strings.Contains(sum, "github.com/b/b v2.0.0 h1:bbb=") {
+		t.Error("expected build info hash appended to go.sum")
+	}
+}
+
+func TestWriteWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteWorkspace(dir, "1.21"); err != nil {
+		t.Fatalf("WriteWorkspace returned error: %v", err)
+	}
+
+	gomod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("go.mod not written: %v", err)
+	}
+	content := string(gomod)
+	if !strings.Contains(content, "module goforgo\n") {
+		t.Errorf("unexpected module path in:\n%s", content)
+	}
+	if strings.Contains(content, "go 1.21\n") {
+		t.Error("expected go directive raised to goforgo's own version")
+	}
+	if !strings.Contains(content, "github.com/sirupsen/logrus v") {
+		t.Error("expected exercise dependencies to be required")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.sum")); err != nil {
+		t.Errorf("go.sum not written: %v", err)
+	}
+
+	if !IsWorkspaceFile(dir, filepath.Join(dir, "go.mod")) || IsWorkspaceFile(dir, filepath.Join(dir, "sub", "go.mod")) {
+		t.Error("IsWorkspaceFile should only match files at the workspace root")
+	}
+}
+
+func TestGoEnv(t *testing.T) {
+	dir := t.TempDir()
+	if env := GoEnv(dir); env != nil {
+		t.Errorf("expected no overrides without a bundle, got %v", env)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, BundleDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	env := GoEnv(dir)
+	if len(env) == 0 || !strings.HasPrefix(env[0], "GOPROXY=file://") {
+		t.Errorf("expected GOPROXY pointing at the bundle, got %v", env)
+	}
+}
@@ -2,13 +2,18 @@ package exercise
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
+	goforgo "github.com/stonecharioteer/goforgo"
+	"github.com/stonecharioteer/goforgo/internal/toolchain"
 )
 
 // Exercise represents a single GoForGo exercise
@@ -19,13 +24,36 @@ type Exercise struct {
 	SolutionPath string `toml:"-"` // Path to the solution file
 	TestFilePath string `toml:"-"` // Path to the _test.go file
 
+	// Multi-file exercises
+	Dir         string   `toml:"-"` // Package root for directory-based exercises
+	SolutionDir string   `toml:"-"` // Solution counterpart of Dir, under the category's testdata
+	Files       []string `toml:"-"` // Every learner-editable .go file of the exercise
+
+	// Solution-owned tests run against the learner's code instead of the visible ones
+	HiddenTests []HiddenTest `toml:"-"`
+
+	// Solution copy of the mutation target when validation.mutation.use_solution is set:
+	// its slash path under the embedded solutions/ tree and its on-disk path
+	MutationSource     string `toml:"-"`
+	MutationSourcePath string `toml:"-"`
+
+	// Unavailable explains why the exercise can't run with the installed toolchain; empty when it can
+	Unavailable string `toml:"-"`
+
+	// Related holds the exercises named by metadata.related_exercises, resolved at load time
+	Related []*Exercise `toml:"-"`
+
+	// Requires holds the exercises named by exercise.prerequisites, resolved at load time
+	Requires []*Exercise `toml:"-"`
+
 	// Metadata from TOML file
 	Info        ExerciseInfo        `toml:"exercise"`
 	Description ExerciseDescription `toml:"description"`
 	Validation  ExerciseValidation  `toml:"validation"`
 	Hints       ExerciseHints       `toml:"hints"`
+	Metadata    ExerciseMetadata    `toml:"metadata"`
 
-	// Runtime state
+	// Runtime state; Attempts (failed runs) and LastAttempt are restored from the progress history
 	Completed   bool      `toml:"-"`
 	LastAttempt time.Time `toml:"-"`
 	Attempts    int       `toml:"-"`
@@ -39,6 +67,20 @@ type ExerciseInfo struct {
 	Difficulty    int    `toml:"difficulty"`           // 1-5 scale
 	EstimatedTime string `toml:"estimated_time"`       // e.g., "5m", "15m"
 	GoVersion     string `toml:"go_version,omitempty"` // Minimum Go version required
+
+	// Multi-file exercises declare either a package directory or a list of files,
+	// both relative to the TOML file. Main selects the package to run in a directory.
+	Dir   string   `toml:"dir,omitempty"`
+	Files []string `toml:"files,omitempty"`
+	Main  string   `toml:"main,omitempty"`
+
+	// Exercises that unlock this one once completed, as paths relative to the exercises
+	// directory like metadata.related_exercises, e.g. "42_kafka/producers.go"
+	Prerequisites []string `toml:"prerequisites,omitempty"`
+
+	// Former names or paths of the exercise, e.g. "hello" or "01_basics/hello.go", so
+	// progress imported from an older goforgo follows renames and merges
+	Aliases []string `toml:"aliases,omitempty"`
 }
 
 // ExerciseDescription contains learning content
@@ -48,13 +90,230 @@ type ExerciseDescription struct {
 	LearningObjectives []string `toml:"learning_objectives"`
 }
 
+// ExerciseMetadata contains tags and cross-references used for browsing exercises
+type ExerciseMetadata struct {
+	Tags             []string `toml:"tags,omitempty"`
+	RelatedExercises []string `toml:"related_exercises,omitempty"` // e.g. "11_concurrency/channels_basics.go", relative to the exercises directory
+}
+
 // ExerciseValidation contains validation configuration
 type ExerciseValidation struct {
-	Mode           string   `toml:"mode"`                      // "build", "test", "run", "static"
-	Timeout        string   `toml:"timeout"`                   // e.g., "30s"
-	ExpectedOutput string   `toml:"expected_output,omitempty"` // Expected program output
-	StaticCheck    string   `toml:"static_check,omitempty"`    // Name of the static analysis check
-	RequiredFiles  []string `toml:"required_files,omitempty"`
+	Mode               string   `toml:"mode"`                           // "build", "test", "run", "static", "bench", "fuzz", "mutation"
+	Timeout            string   `toml:"timeout"`                        // e.g., "30s"
+	ExpectedOutput     string   `toml:"expected_output,omitempty"`      // Expected program output
+	ExpectedOutputMode string   `toml:"expected_output_mode,omitempty"` // "exact" (default), "normalized_whitespace", "line_set", "regex", "json"
+	StaticCheck        string   `toml:"static_check,omitempty"`         // Name of the static analysis check
+	RequiredFiles      []string `toml:"required_files,omitempty"`
+
+	StaticArgs map[string]string `toml:"static_args,omitempty"` // Arguments for the static check, e.g. {path = "fmt"}
+
+	// Static checks to combine; in modes other than "static" they run after the main validation passes
+	StaticChecks []StaticCheckSpec `toml:"static_checks,omitempty"`
+	StaticMatch  string            `toml:"static_match,omitempty"` // "all" (default) or "any" of the static checks must pass
+
+	// External analyzers, whose diagnostics count as static checks
+	Analyzers []AnalyzerSpec `toml:"analyzers,omitempty"`
+
+	Quality QualityConfig `toml:"quality,omitempty"` // Quality gate run after the main validation
+
+	// Run-mode input fixtures, used when no [[validation.cases]] are declared
+	Stdin string            `toml:"stdin,omitempty"` // Text fed to the program's standard input
+	Args  []string          `toml:"args,omitempty"`  // Command-line arguments passed to the program
+	Env   map[string]string `toml:"env,omitempty"`   // Extra environment variables for the program
+
+	Cases []ValidationCase `toml:"cases,omitempty"` // Named run-mode test cases
+
+	Sandbox string `toml:"sandbox,omitempty"` // "none" (default), "basic" or "strict" (Linux only)
+
+	TodoPolicy string `toml:"todo_policy,omitempty"` // "all" (default), "required" or "none"
+
+	// Hidden reference tests for test mode, relative to the exercise's solution directory
+	HiddenTestFiles []string `toml:"hidden_tests,omitempty"`
+	HiddenDetail    string   `toml:"hidden_detail,omitempty"` // "summary", "names" (default) or "full"
+
+	// Coverage targets for test mode; tests must also pass
+	MinCoverage float64  `toml:"min_coverage,omitempty"` // Minimum statement coverage, in percent
+	MustCover   []string `toml:"must_cover,omitempty"`   // Functions the tests must execute, e.g. "Add" or "Stack.Push"
+
+	Bench    BenchConfig    `toml:"bench,omitempty"`    // Settings for mode = "bench"
+	Fuzz     FuzzConfig     `toml:"fuzz,omitempty"`     // Settings for mode = "fuzz"
+	Mutation MutationConfig `toml:"mutation,omitempty"` // Settings for mode = "mutation"
+}
+
+// StaticCheckSpec names a registered static check and its arguments, e.g.
+// {name = "forbid_import", args = {path = "fmt"}}
+type StaticCheckSpec struct {
+	Name string            `toml:"name"`
+	Args map[string]string `toml:"args,omitempty"`
+}
+
+// SandboxModes are the values of validation.sandbox; the runner defines what each one limits
+var SandboxModes = []string{"none", "basic", "strict"}
+
+// QualityConfig sets how the quality gate treats each kind of finding: "off", "warn" or
+// "block". Unset entries fall back to the machine-wide GOFORGO_QUALITY level.
+type QualityConfig struct {
+	Gofmt     string `toml:"gofmt,omitempty"`     // Files that gofmt would change
+	Vet       string `toml:"vet,omitempty"`       // go vet findings
+	Analyzers string `toml:"analyzers,omitempty"` // Findings of goforgo's curated analyzers
+}
+
+// validate rejects unknown quality levels
+func (q QualityConfig) validate() error {
+	for _, entry := range [][2]string{{"gofmt", q.Gofmt}, {"vet", q.Vet}, {"analyzers", q.Analyzers}} {
+		switch entry[1] {
+		case "", "off", "warn", "block":
+		default:
+			return fmt.Errorf("unknown quality level %q for %s (want off, warn or block)", entry[1], entry[0])
+		}
+	}
+	return nil
+}
+
+// AnalyzerSpec declares an external analyzer binary, either prebuilt (Path) or built from
+// a Go package (Module). Any diagnostic it reports fails the check.
+type AnalyzerSpec struct {
+	Name     string   `toml:"name,omitempty"`     // Shown in reports; defaults to the binary or package name
+	Path     string   `toml:"path,omitempty"`     // Binary, relative to the exercise TOML unless absolute
+	Module   string   `toml:"module,omitempty"`   // main package, e.g. "golang.org/x/tools/go/analysis/passes/nilness/cmd/nilness@v0.34.0"
+	Protocol string   `toml:"protocol,omitempty"` // "vettool" (default, run by go vet -vettool) or "json" (run directly with -json)
+	Flags    []string `toml:"flags,omitempty"`    // Extra flags, e.g. ["-name=Println"]
+}
+
+// DisplayName returns Name, falling back to the last element of the binary or package path
+func (a AnalyzerSpec) DisplayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	if a.Module != "" {
+		module, _, _ := strings.Cut(a.Module, "@")
+		return path.Base(module)
+	}
+	return strings.TrimSuffix(filepath.Base(a.Path), ".exe")
+}
+
+// HiddenTest is a solution-owned _test.go file that is copied next to the learner's
+// code in a private build directory at validation time, so it can't be edited.
+type HiddenTest struct {
+	Name     string // Base name, e.g. "stack_queue_hidden_test.go"
+	Embedded string // Slash path under the embedded solutions/ tree, preferred when present
+	Path     string // On-disk copy in the solutions directory
+}
+
+// MutationConfig configures mutation-mode exercises, where the learner writes the tests
+// and is graded on how many small bugs planted in the implementation they catch.
+type MutationConfig struct {
+	Target     string   `toml:"target"`                // Implementation file to mutate, relative to the exercise
+	Threshold  float64  `toml:"threshold,omitempty"`   // Share of mutants the tests must kill, default 0.8
+	Operators  []string `toml:"operators,omitempty"`   // Subset of "comparison", "arithmetic", "boolean", "constant", "return"
+	MaxMutants int      `toml:"max_mutants,omitempty"` // Cap on mutants tried, default 40
+
+	// UseSolution mutates the solution's copy of Target, so the score doesn't depend on
+	// the learner's own implementation
+	UseSolution bool `toml:"use_solution,omitempty"`
+}
+
+// FuzzConfig configures fuzz-mode exercises. The seed corpus lives in
+// testdata/fuzz/<Target> next to the exercise, as go test expects.
+type FuzzConfig struct {
+	Target   string `toml:"target"`             // Fuzz function, e.g. "FuzzReverse"
+	Function string `toml:"function,omitempty"` // Function under test the fuzz body must call
+	Fuzztime string `toml:"fuzztime,omitempty"` // -fuzztime budget, default "10s"
+}
+
+// BenchConfig configures go test -bench for bench-mode exercises
+type BenchConfig struct {
+	Pattern    string           `toml:"pattern,omitempty"`   // -bench regexp, default "."
+	Count      int              `toml:"count,omitempty"`     // -count, default 5
+	Benchtime  string           `toml:"benchtime,omitempty"` // -benchtime, default "100ms"
+	Thresholds []BenchThreshold `toml:"thresholds,omitempty"`
+}
+
+// BenchThreshold is a performance target for one benchmark. Any combination of
+// limits may be set; all of them must hold.
+type BenchThreshold struct {
+	Benchmark      string  `toml:"benchmark"`                   // e.g. "BenchmarkConcatBuilder"
+	MaxNsPerOp     float64 `toml:"max_ns_per_op,omitempty"`     // Median ns/op must not exceed this
+	MaxAllocsPerOp *int    `toml:"max_allocs_per_op,omitempty"` // Pointer so 0 allocs can be required
+	FasterThan     string  `toml:"faster_than,omitempty"`       // Baseline benchmark shipped with the exercise
+	MinSpeedup     float64 `toml:"min_speedup,omitempty"`       // Required baseline/target ns/op ratio, default 1
+}
+
+// RequiredSpeedup returns the baseline/target ns/op ratio faster_than requires. Without
+// min_speedup the target must be at least as fast as the baseline.
+func (t BenchThreshold) RequiredSpeedup() float64 {
+	if t.MinSpeedup == 0 {
+		return 1
+	}
+	return t.MinSpeedup
+}
+
+// validate rejects speedups that any benchmark would meet
+func (c BenchConfig) validate() error {
+	for _, t := range c.Thresholds {
+		if t.MinSpeedup < 0 {
+			return fmt.Errorf("min_speedup of %s must be positive, got %g", t.Benchmark, t.MinSpeedup)
+		}
+		if t.MinSpeedup != 0 && t.FasterThan == "" {
+			return fmt.Errorf("min_speedup of %s needs faster_than to name a baseline", t.Benchmark)
+		}
+	}
+	return nil
+}
+
+// ValidationCase is a single named run-mode input/output pair
+type ValidationCase struct {
+	Name               string            `toml:"name"`
+	Stdin              string            `toml:"stdin,omitempty"`
+	Args               []string          `toml:"args,omitempty"`
+	Env                map[string]string `toml:"env,omitempty"`
+	ExpectedOutput     string            `toml:"expected_output,omitempty"`
+	ExpectedOutputMode string            `toml:"expected_output_mode,omitempty"` // Defaults to the exercise-level mode
+}
+
+// WantsCoverage reports whether test mode should collect a cover profile
+func (v ExerciseValidation) WantsCoverage() bool {
+	return v.MinCoverage > 0 || len(v.MustCover) > 0
+}
+
+// HasStaticChecks reports whether the exercise declares any static check or external analyzer
+func (v ExerciseValidation) HasStaticChecks() bool {
+	return len(v.StaticCheckList()) > 0 || len(v.Analyzers) > 0
+}
+
+// StaticCheckList returns every static check the exercise declares: the single
+// static_check, if set, followed by the static_checks list.
+func (v ExerciseValidation) StaticCheckList() []StaticCheckSpec {
+	if v.StaticCheck == "" {
+		return v.StaticChecks
+	}
+	return append([]StaticCheckSpec{{Name: v.StaticCheck, Args: v.StaticArgs}}, v.StaticChecks...)
+}
+
+// RunCases returns the run-mode cases for an exercise. Exercises without explicit
+// cases get a single case built from the top-level stdin/args/env/expected_output fields.
+func (v ExerciseValidation) RunCases() []ValidationCase {
+	if len(v.Cases) == 0 {
+		return []ValidationCase{{
+			Stdin:              v.Stdin,
+			Args:               v.Args,
+			Env:                v.Env,
+			ExpectedOutput:     v.ExpectedOutput,
+			ExpectedOutputMode: v.ExpectedOutputMode,
+		}}
+	}
+
+	cases := make([]ValidationCase, len(v.Cases))
+	for i, c := range v.Cases {
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case %d", i+1)
+		}
+		if c.ExpectedOutputMode == "" {
+			c.ExpectedOutputMode = v.ExpectedOutputMode
+		}
+		cases[i] = c
+	}
+	return cases
 }
 
 // ExerciseHints contains progressive hints
@@ -68,32 +327,62 @@ type ExerciseHints struct {
 type ExerciseManager struct {
 	ExercisesPath string
 	SolutionsPath string
-	ProgressPath  string
+	ProgressPath  string // Local file holding the progress, "" when the progress store isn't local
+	TracksPath    string // Workspace track definitions, overriding built-in tracks of the same name
+	BuiltinTracks fs.FS  // Track definitions shipped with goforgo
+	Quiet         bool   // Don't print the loaded count, for machine-readable output
+	Strict        bool   // Fail on related exercises or prerequisites that don't exist, as CI does
 	exercises     []*Exercise
+	warnings      []string // Problems loading the exercises or progress that were worked around
 	progress      *Progress
+	scope         *trackScope // Active track, nil for the whole course
+	store         ProgressStore
+	progressErr   error     // Why the stored progress must not be overwritten, if it mustn't
+	progressBase  *Progress // The stored progress as last read or written, to merge other processes' saves
+	progressRev   string    // Its revision, to notice when another process changes it
 }
 
 // Progress tracks user progress through exercises
 type Progress struct {
-	CompletedExercises map[string]bool `toml:"completed_exercises"`
-	CurrentExercise    string          `toml:"current_exercise"`
-	LastUpdated        time.Time       `toml:"last_updated"`
+	Version            int                         `toml:"version"` // Schema version, see ProgressVersion
+	CompletedExercises map[string]bool             `toml:"completed_exercises"`
+	CurrentExercise    string                      `toml:"current_exercise"`
+	LastCompleted      string                      `toml:"last_completed,omitempty"` // Exercise completed most recently, to recommend what follows it
+	ActiveTrack        string                      `toml:"active_track,omitempty"`
+	LastUpdated        time.Time                   `toml:"last_updated"`
+	History            map[string]*ExerciseHistory `toml:"history,omitempty"`  // By exercise name
+	Activity           map[string]*DailyActivity   `toml:"activity,omitempty"` // By local date, e.g. "2026-10-19"
 }
 
-// NewExerciseManager creates a new exercise manager
+// NewExerciseManager creates a new exercise manager. Progress is kept in the store
+// selected by GOFORGO_PROGRESS_STORE, the workspace's TOML file by default.
 func NewExerciseManager(basePath string) *ExerciseManager {
 	em := &ExerciseManager{
 		ExercisesPath: filepath.Join(basePath, "exercises"),
 		SolutionsPath: filepath.Join(basePath, "solutions"),
-		ProgressPath:  filepath.Join(basePath, ".goforgo-progress.toml"),
+		TracksPath:    filepath.Join(basePath, "tracks"),
 		exercises:     make([]*Exercise, 0),
-		progress: &Progress{
-			CompletedExercises: make(map[string]bool),
-		},
+		progress:      newProgress(),
+	}
+
+	if tracks, err := fs.Sub(goforgo.Content, "tracks"); err == nil {
+		em.BuiltinTracks = tracks
+	}
+
+	store, err := OpenProgressStore(basePath, os.Getenv(ProgressStoreEnv))
+	if _, isFile := store.(*TOMLStore); err == nil && !isFile {
+		// Progress kept in the file so far moves along to a new store. If it can't,
+		// nothing is saved, so the store doesn't start over without it.
+		err = importTOMLProgress(basePath, store)
+	}
+	if err != nil {
+		store = unavailableStore{err: err}
 	}
+	em.store = store
+	em.ProgressPath = store.Path()
 
-	// Load existing progress
-	em.loadProgress()
+	// Load existing progress; LoadExercises reports why it can't be saved over, if it can't
+	_ = em.loadProgress()
 
 	return em
 }
@@ -106,6 +395,8 @@ func (em *ExerciseManager) LoadExercises() error {
 
 	// Reset in-memory list before reloading to avoid duplicate growth on repeated calls.
 	em.exercises = em.exercises[:0]
+	em.warnings = nil
+	em.warn(em.progressErr)
 
 	// Walk through the exercises directory
 	err := filepath.Walk(em.ExercisesPath, func(path string, info os.FileInfo, err error) error {
@@ -133,6 +424,10 @@ func (em *ExerciseManager) LoadExercises() error {
 		return fmt.Errorf("no exercises found in %s. Run 'goforgo init' to set up exercises", em.ExercisesPath)
 	}
 
+	if err := em.resolveLinks(); err != nil {
+		return err
+	}
+
 	// Sort exercises by category and then by order within category
 	sort.Slice(em.exercises, func(i, j int) bool {
 		if em.exercises[i].Info.Category != em.exercises[j].Info.Category {
@@ -144,7 +439,17 @@ func (em *ExerciseManager) LoadExercises() error {
 	// Update exercise completion status based on saved progress
 	em.UpdateExerciseProgress()
 
-	fmt.Printf("📚 Loaded %d exercises\n", len(em.exercises))
+	// Scope to the track chosen with 'goforgo track use'
+	em.warn(em.applyActiveTrack())
+
+	// Mark exercises the installed toolchain is too old for
+	if installed, err := toolchain.Detect(); err == nil {
+		em.ApplyToolchain(installed)
+	}
+
+	if !em.Quiet {
+		fmt.Printf("📚 Loaded %d exercises\n", len(em.exercises))
+	}
 	return nil
 }
 
@@ -163,7 +468,45 @@ func (em *ExerciseManager) loadExercise(metadataPath string) (*Exercise, error)
 	dir := filepath.Dir(metadataPath)
 	baseName := strings.TrimSuffix(filepath.Base(metadataPath), ".toml")
 
+	// Determine solution directory
+	relDir, err := filepath.Rel(em.ExercisesPath, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine relative path: %w", err)
+	}
+	solutionDir := filepath.Join(em.SolutionsPath, relDir)
+
+	if err := exercise.resolveAnalyzers(dir); err != nil {
+		return nil, err
+	}
+	if err := exercise.Validation.Quality.validate(); err != nil {
+		return nil, err
+	}
+	if err := exercise.Validation.Bench.validate(); err != nil {
+		return nil, err
+	}
+	if sandbox := exercise.Validation.Sandbox; sandbox != "" && !slices.Contains(SandboxModes, sandbox) {
+		return nil, fmt.Errorf("unknown sandbox %q (want %s)", sandbox, strings.Join(SandboxModes, ", "))
+	}
+
+	if err := exercise.resolveMutationTarget(relDir, solutionDir); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case exercise.Info.Dir != "":
+		if err := exercise.resolvePackage(dir, solutionDir); err != nil {
+			return nil, err
+		}
+		return exercise, exercise.resolveHiddenTests(relDir, solutionDir)
+	case len(exercise.Info.Files) > 0:
+		if err := exercise.resolveFiles(dir, solutionDir); err != nil {
+			return nil, err
+		}
+		return exercise, exercise.resolveHiddenTests(relDir, solutionDir)
+	}
+
 	exercise.FilePath = filepath.Join(dir, baseName+".go")
+	exercise.Files = []string{exercise.FilePath}
 
 	// Determine test file path
 	testFilePath := filepath.Join(dir, baseName+"_test.go")
@@ -176,18 +519,364 @@ func (em *ExerciseManager) loadExercise(metadataPath string) (*Exercise, error)
 		return nil, fmt.Errorf("go file not found at %s", exercise.FilePath)
 	}
 
-	// Determine solution path
-	relDir, err := filepath.Rel(em.ExercisesPath, dir)
+	exercise.SolutionPath = filepath.Join(solutionDir, baseName+".go")
+
+	return exercise, exercise.resolveHiddenTests(relDir, solutionDir)
+}
+
+// resolveHiddenTests fills in HiddenTests from validation.hidden_tests. relDir is the
+// TOML's directory relative to the exercises root; solutionDir is its solutions counterpart.
+func (e *Exercise) resolveHiddenTests(relDir, solutionDir string) error {
+	for _, name := range e.Validation.HiddenTestFiles {
+		if !strings.HasSuffix(name, "_test.go") || filepath.IsAbs(name) || strings.Contains(filepath.ToSlash(name), "..") {
+			return fmt.Errorf("hidden test %q must be a _test.go file inside the solution directory", name)
+		}
+		e.HiddenTests = append(e.HiddenTests, HiddenTest{
+			Name:     filepath.Base(name),
+			Embedded: path.Join("solutions", filepath.ToSlash(relDir), filepath.ToSlash(name)),
+			Path:     filepath.Join(solutionDir, name),
+		})
+	}
+
+	switch e.Validation.HiddenDetail {
+	case "", "summary", "names", "full":
+		return nil
+	default:
+		return fmt.Errorf("unknown hidden_detail %q (want summary, names or full)", e.Validation.HiddenDetail)
+	}
+}
+
+// resolveMutationTarget checks validation.mutation.target and, with use_solution, fills
+// in MutationSource. relDir and solutionDir are as for resolveHiddenTests.
+func (e *Exercise) resolveMutationTarget(relDir, solutionDir string) error {
+	m := e.Validation.Mutation
+	if m.Target == "" {
+		return nil
+	}
+	if !strings.HasSuffix(m.Target, ".go") || strings.HasSuffix(m.Target, "_test.go") ||
+		filepath.IsAbs(m.Target) || strings.Contains(filepath.ToSlash(m.Target), "..") {
+		return fmt.Errorf("mutation target %q must be a non-test .go file inside the exercise", m.Target)
+	}
+	if m.UseSolution {
+		e.MutationSource = path.Join("solutions", filepath.ToSlash(relDir), filepath.ToSlash(m.Target))
+		e.MutationSourcePath = filepath.Join(solutionDir, m.Target)
+	}
+	return nil
+}
+
+// resolveAnalyzers validates the external analyzers and makes their binary paths
+// absolute, relative to dir, the TOML's directory.
+func (e *Exercise) resolveAnalyzers(dir string) error {
+	for i := range e.Validation.Analyzers {
+		a := &e.Validation.Analyzers[i]
+		if (a.Path == "") == (a.Module == "") {
+			return fmt.Errorf("analyzer %q must set exactly one of path and module", a.DisplayName())
+		}
+		switch a.Protocol {
+		case "", "vettool", "json":
+		default:
+			return fmt.Errorf("analyzer %q has unknown protocol %q (want vettool or json)", a.DisplayName(), a.Protocol)
+		}
+		if a.Path != "" && !filepath.IsAbs(a.Path) {
+			a.Path = filepath.Join(dir, a.Path)
+		}
+	}
+	return nil
+}
+
+// resolvePackage fills in paths for an exercise declared with dir = "...".
+// The primary file is main.go at the package root, or the first source file found.
+func (e *Exercise) resolvePackage(dir, solutionDir string) error {
+	e.Dir = filepath.Join(dir, e.Info.Dir)
+	// Package solutions import their own packages by workspace module path, which
+	// doesn't resolve in the goforgo repository, so they live under testdata where
+	// 'go build ./...' there skips them
+	e.SolutionDir = filepath.Join(solutionDir, "testdata", e.Info.Dir)
+
+	info, err := os.Stat(e.Dir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("exercise directory not found at %s", e.Dir)
+	}
+
+	err = filepath.Walk(e.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".go") {
+			e.Files = append(e.Files, path)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path: %w", err)
+		return fmt.Errorf("failed to scan exercise directory: %w", err)
+	}
+
+	for _, file := range e.Files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if e.FilePath == "" || file == filepath.Join(e.Dir, "main.go") {
+			e.FilePath = file
+		}
+	}
+	if e.FilePath == "" {
+		return fmt.Errorf("no go files found in %s", e.Dir)
+	}
+
+	rel, _ := filepath.Rel(e.Dir, e.FilePath)
+	e.SolutionPath = filepath.Join(e.SolutionDir, rel)
+	return nil
+}
+
+// resolveFiles fills in paths for an exercise declared with files = [...]
+func (e *Exercise) resolveFiles(dir, solutionDir string) error {
+	for _, name := range e.Info.Files {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("go file not found at %s", path)
+		}
+		e.Files = append(e.Files, path)
+
+		switch {
+		case strings.HasSuffix(name, "_test.go"):
+			if e.TestFilePath == "" {
+				e.TestFilePath = path
+			}
+		case e.FilePath == "":
+			e.FilePath = path
+			e.SolutionPath = filepath.Join(solutionDir, name)
+		}
+	}
+
+	if e.FilePath == "" {
+		return fmt.Errorf("files list for %s has no non-test go file", e.Info.Name)
+	}
+	return nil
+}
+
+// MinGoVersion returns the exercise's minimum Go version as a bare version, e.g. "1.23"
+func (e *Exercise) MinGoVersion() string {
+	return toolchain.Normalize(e.Info.GoVersion)
+}
+
+// IsAvailable reports whether the exercise can run with the installed toolchain
+func (e *Exercise) IsAvailable() bool {
+	return e.Unavailable == ""
+}
+
+// ApplyToolchain marks exercises whose go_version the installed toolchain doesn't meet
+func (em *ExerciseManager) ApplyToolchain(installed string) {
+	for _, ex := range em.exercises {
+		ex.Unavailable = ""
+		if !toolchain.Satisfies(installed, ex.Info.GoVersion) {
+			ex.Unavailable = fmt.Sprintf("requires Go %s or newer (installed: %s)", ex.MinGoVersion(), installed)
+		}
+	}
+}
+
+// IsPackage reports whether the exercise is a directory-based package exercise
+func (e *Exercise) IsPackage() bool {
+	return e.Dir != ""
+}
+
+// WorkDir returns the directory the exercise is built and run from
+func (e *Exercise) WorkDir() string {
+	if e.Dir != "" {
+		return e.Dir
+	}
+	return filepath.Dir(e.FilePath)
+}
+
+// SourceFiles returns the exercise's non-test Go files
+func (e *Exercise) SourceFiles() []string {
+	if len(e.Files) == 0 {
+		return []string{e.FilePath}
+	}
+	var files []string
+	for _, file := range e.Files {
+		if !strings.HasSuffix(file, "_test.go") {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// OwnsPath reports whether a file belongs to the exercise, so edits anywhere in it trigger a re-run
+func (e *Exercise) OwnsPath(path string) bool {
+	if e.Dir != "" {
+		rel, err := filepath.Rel(e.Dir, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+	for _, file := range e.Files {
+		if file == path {
+			return true
+		}
+	}
+	return path == e.TestFilePath
+}
+
+// HasTag reports whether the exercise is tagged with tag, ignoring case
+func (e *Exercise) HasTag(tag string) bool {
+	for _, t := range e.Metadata.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveLinks links every exercise to the exercises its related_exercises and
+// prerequisites name. Paths are relative to the exercises directory and name the
+// exercise's .go file or TOML, with or without an extension. A path matching no
+// exercise is skipped with a warning, since workspaces keep the TOMLs of the goforgo
+// version that created them, unless Strict is set; prerequisites that depend on each
+// other in a cycle are always an error.
+func (em *ExerciseManager) resolveLinks() error {
+	byKey := make(map[string]*Exercise, len(em.exercises))
+	for _, ex := range em.exercises {
+		byKey[em.relatedKey(ex.MetadataPath)] = ex
+	}
+	resolve := func(ex *Exercise, field string, paths []string) ([]*Exercise, error) {
+		var linked []*Exercise
+		for _, rel := range paths {
+			target, ok := byKey[em.relatedKey(filepath.Join(em.ExercisesPath, filepath.FromSlash(rel)))]
+			if !ok && em.Strict {
+				return nil, fmt.Errorf("%s: %s exercise %q not found", ex.MetadataPath, field, rel)
+			}
+			if !ok {
+				em.warnings = append(em.warnings, fmt.Sprintf("%s: %s exercise %q not found; ignoring it", ex.MetadataPath, field, rel))
+				continue
+			}
+			linked = append(linked, target)
+		}
+		return linked, nil
+	}
+
+	for _, ex := range em.exercises {
+		var err error
+		if ex.Related, err = resolve(ex, "related", ex.Metadata.RelatedExercises); err != nil {
+			return err
+		}
+		if ex.Requires, err = resolve(ex, "prerequisite", ex.Info.Prerequisites); err != nil {
+			return err
+		}
 	}
-	exercise.SolutionPath = filepath.Join(em.SolutionsPath, relDir, baseName+".go")
+	return em.checkPrerequisiteCycles()
+}
+
+// Warnings returns the problems the last LoadExercises skipped over, such as links to
+// exercises that don't exist or progress that can't be saved over
+func (em *ExerciseManager) Warnings() []string {
+	return em.warnings
+}
 
-	return exercise, nil
+// warn records err, if any, as a warning
+func (em *ExerciseManager) warn(err error) {
+	if err != nil {
+		em.warnings = append(em.warnings, err.Error())
+	}
+}
+
+// checkPrerequisiteCycles returns an error naming the first cycle among prerequisites
+func (em *ExerciseManager) checkPrerequisiteCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*Exercise]int, len(em.exercises))
+	var stack []*Exercise
+	var visit func(ex *Exercise) error
+	visit = func(ex *Exercise) error {
+		switch state[ex] {
+		case visiting:
+			start := len(stack) - 1
+			for stack[start] != ex {
+				start--
+			}
+			var names []string
+			for _, e := range append(stack[start:], ex) {
+				names = append(names, em.relatedKey(e.MetadataPath))
+			}
+			return fmt.Errorf("prerequisite cycle: %s", strings.Join(names, " -> "))
+		case done:
+			return nil
+		}
+		state[ex] = visiting
+		stack = append(stack, ex)
+		for _, req := range ex.Requires {
+			if err := visit(req); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[ex] = done
+		return nil
+	}
+	for _, ex := range em.exercises {
+		if err := visit(ex); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// GetExercises returns all loaded exercises
+// relatedKey maps an exercise file path to its slash path under the exercises
+// directory with the extension removed, e.g. "11_concurrency/channels_basics"
+func (em *ExerciseManager) relatedKey(path string) string {
+	rel, err := filepath.Rel(em.ExercisesPath, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+	for _, ext := range []string{".toml", ".go"} {
+		rel = strings.TrimSuffix(rel, ext)
+	}
+	return rel
+}
+
+// ResolveExercise finds the exercise that a name or path recorded by another goforgo
+// version refers to: the exercise with that name or path, or one listing it in its
+// aliases. It returns nil for exercises that no longer exist.
+func (em *ExerciseManager) ResolveExercise(ref string) *Exercise {
+	key := em.relatedKey(filepath.Join(em.ExercisesPath, filepath.FromSlash(ref)))
+	for _, ex := range em.exercises {
+		if ex.Info.Name == ref {
+			return ex
+		}
+	}
+	for _, ex := range em.exercises {
+		if em.relatedKey(ex.MetadataPath) == key {
+			return ex
+		}
+	}
+	for _, ex := range em.exercises {
+		for _, alias := range ex.Info.Aliases {
+			if alias == ref || em.relatedKey(filepath.Join(em.ExercisesPath, filepath.FromSlash(alias))) == key {
+				return ex
+			}
+		}
+	}
+	return nil
+}
+
+// ExerciseKey returns the path identifying an exercise across goforgo versions, relative
+// to the exercises directory without extension, e.g. "01_basics/hello"
+func (em *ExerciseManager) ExerciseKey(ex *Exercise) string {
+	return em.relatedKey(ex.MetadataPath)
+}
+
+// GetExercises returns the exercises of the active track, in track order, or all loaded
+// exercises when no track is active
 func (em *ExerciseManager) GetExercises() []*Exercise {
+	if em.scope != nil {
+		return em.scope.exercises
+	}
+	return em.exercises
+}
+
+// AllExercises returns every loaded exercise, regardless of the active track
+func (em *ExerciseManager) AllExercises() []*Exercise {
 	return em.exercises
 }
 
@@ -201,21 +890,119 @@ func (em *ExerciseManager) GetExerciseByName(name string) (*Exercise, error) {
 	return nil, fmt.Errorf("exercise '%s' not found", name)
 }
 
-// GetNextExercise returns the next incomplete exercise
-func (em *ExerciseManager) GetNextExercise() *Exercise {
-	for _, exercise := range em.exercises {
-		if !exercise.Completed {
-			return exercise
+// IsUnlocked reports whether every prerequisite of the exercise is completed
+func (e *Exercise) IsUnlocked() bool {
+	for _, req := range e.Requires {
+		if !req.Completed {
+			return false
 		}
 	}
+	return true
+}
+
+// GetNextExercise returns the recommended next exercise: the first of RecommendedExercises
+func (em *ExerciseManager) GetNextExercise() *Exercise {
+	if next := em.RecommendedExercises(1); len(next) > 0 {
+		return next[0]
+	}
 	return nil // All exercises completed
 }
 
+// RecommendedExercises returns up to limit incomplete exercises the learner can start now,
+// or all of them when limit <= 0. The exercises the most recently completed one unlocked
+// come first, then the rest of its category, so the learner carries on with the topic
+// they're working on rather than going back to the first exercise in the course; the
+// rest follow in course (or track) order. Optional track exercises come last.
+func (em *ExerciseManager) RecommendedExercises(limit int) []*Exercise {
+	var last *Exercise
+	if em.progress != nil {
+		last, _ = em.GetExerciseByName(em.progress.LastCompleted)
+	}
+
+	var unlocked, sameCategory, others, optional []*Exercise
+	for _, exercise := range em.GetExercises() {
+		// Exercises the installed toolchain can't run are skipped rather than handed out
+		if exercise.Completed || !exercise.IsAvailable() || !exercise.IsUnlocked() {
+			continue
+		}
+		switch {
+		case em.IsOptional(exercise):
+			optional = append(optional, exercise)
+		case last != nil && slices.Contains(exercise.Requires, last):
+			unlocked = append(unlocked, exercise)
+		case last != nil && exercise.Info.Category == last.Info.Category:
+			sameCategory = append(sameCategory, exercise)
+		default:
+			others = append(others, exercise)
+		}
+	}
+	recommended := slices.Concat(unlocked, sameCategory, others, optional)
+	if limit > 0 && len(recommended) > limit {
+		recommended = recommended[:limit]
+	}
+	return recommended
+}
+
+// PathTo returns the incomplete exercises needed to finish targets, including the targets
+// themselves, with every exercise after its prerequisites. Completed exercises, and the
+// prerequisites only they needed, are left out.
+func (em *ExerciseManager) PathTo(targets []*Exercise) []*Exercise {
+	seen := make(map[*Exercise]bool)
+	var path []*Exercise
+	var visit func(ex *Exercise)
+	visit = func(ex *Exercise) {
+		if seen[ex] || ex.Completed {
+			return
+		}
+		seen[ex] = true
+		for _, req := range ex.Requires {
+			visit(req)
+		}
+		path = append(path, ex)
+	}
+	for _, ex := range targets {
+		visit(ex)
+	}
+	return path
+}
+
+// FindTarget resolves a learning path target to exercises. It tries, in order, an
+// exercise path such as "42_kafka/streams", exercise names, a category with or without
+// its number prefix such as "42_kafka" or "kafka", and finally a metadata tag.
+func (em *ExerciseManager) FindTarget(target string) ([]*Exercise, error) {
+	key := em.relatedKey(filepath.Join(em.ExercisesPath, filepath.FromSlash(target)))
+	matchers := []func(ex *Exercise) bool{
+		func(ex *Exercise) bool { return em.relatedKey(ex.MetadataPath) == key },
+		func(ex *Exercise) bool { return ex.Info.Name == target },
+		func(ex *Exercise) bool {
+			_, name, _ := strings.Cut(ex.Info.Category, "_")
+			return strings.EqualFold(ex.Info.Category, target) || strings.EqualFold(name, target)
+		},
+		func(ex *Exercise) bool { return ex.HasTag(target) },
+	}
+	for _, match := range matchers {
+		var found []*Exercise
+		for _, ex := range em.exercises {
+			if match(ex) {
+				found = append(found, ex)
+			}
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("no exercise, category or tag matches '%s'", target)
+}
+
 // String returns a human-readable representation of the exercise
 func (e *Exercise) String() string {
 	status := "❌"
 	if e.Completed {
 		status = "✅"
+	} else if !e.IsAvailable() {
+		status = "🚫"
+	} else if !e.IsUnlocked() {
+		status = "🔒"
 	}
 	return fmt.Sprintf("%s %s/%s: %s", status, e.Info.Category, e.Info.Name, e.Description.Title)
 }
@@ -238,7 +1025,8 @@ func (e *Exercise) GetDifficultyString() string {
 	}
 }
 
-// GetHint returns the appropriate hint based on attempt count
+// GetHint returns the appropriate hint based on the number of failed runs, which is
+// persisted across sessions
 func (e *Exercise) GetHint() string {
 	switch {
 	case e.Attempts <= 2 && e.Hints.Level1 != "":
@@ -254,43 +1042,6 @@ func (e *Exercise) GetHint() string {
 	}
 }
 
-// loadProgress loads user progress from the progress file
-func (em *ExerciseManager) loadProgress() {
-	if _, err := os.Stat(em.ProgressPath); os.IsNotExist(err) {
-		// No progress file exists yet, start fresh
-		return
-	}
-
-	if _, err := toml.DecodeFile(em.ProgressPath, em.progress); err != nil {
-		// Failed to load progress, start fresh
-		em.progress = &Progress{
-			CompletedExercises: make(map[string]bool),
-		}
-	}
-}
-
-// saveProgress saves user progress to the progress file
-func (em *ExerciseManager) saveProgress() error {
-	em.progress.LastUpdated = time.Now()
-
-	file, err := os.Create(em.ProgressPath)
-	if err != nil {
-		return fmt.Errorf("failed to create progress file: %w", err)
-	}
-
-	encoder := toml.NewEncoder(file)
-	if err := encoder.Encode(em.progress); err != nil {
-		_ = file.Close()
-		return fmt.Errorf("failed to encode progress: %w", err)
-	}
-
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("failed to close progress file: %w", err)
-	}
-
-	return nil
-}
-
 // MarkExerciseCompleted marks an exercise as completed and updates progress
 func (em *ExerciseManager) MarkExerciseCompleted(exerciseName string) error {
 	// Find and mark the exercise as completed
@@ -302,6 +1053,13 @@ func (em *ExerciseManager) MarkExerciseCompleted(exerciseName string) error {
 	}
 
 	// Update progress tracking
+	if !em.progress.CompletedExercises[exerciseName] {
+		h := em.history(exerciseName)
+		h.CompletedAt = time.Now()
+		h.RunsToComplete = h.Runs
+		em.activity().Completions++
+		em.progress.LastCompleted = exerciseName
+	}
 	em.progress.CompletedExercises[exerciseName] = true
 
 	// Set next exercise as current
@@ -326,6 +1084,10 @@ func (em *ExerciseManager) UnmarkExerciseCompleted(exerciseName string) error {
 	}
 
 	delete(em.progress.CompletedExercises, exerciseName)
+	if h := em.progress.History[exerciseName]; h != nil {
+		h.CompletedAt = time.Time{}
+		h.RunsToComplete = 0
+	}
 
 	return em.saveProgress()
 }
@@ -333,19 +1095,32 @@ func (em *ExerciseManager) UnmarkExerciseCompleted(exerciseName string) error {
 // UpdateExerciseProgress updates the completion status of exercises based on progress
 func (em *ExerciseManager) UpdateExerciseProgress() {
 	for _, exercise := range em.exercises {
-		if completed, exists := em.progress.CompletedExercises[exercise.Info.Name]; exists && completed {
-			exercise.Completed = true
+		exercise.Completed = em.progress.CompletedExercises[exercise.Info.Name]
+		exercise.Attempts, exercise.LastAttempt = 0, time.Time{}
+		if h := em.progress.History[exercise.Info.Name]; h != nil {
+			exercise.Attempts = h.Failures
+			exercise.LastAttempt = h.LastRun
 		}
 	}
 }
 
-// GetTotalExerciseCount returns the total number of loaded exercises
+// GetTotalExerciseCount returns the total number of loaded exercises, or of required
+// exercises in the active track
 func (em *ExerciseManager) GetTotalExerciseCount() int {
+	if em.scope != nil {
+		_, total := em.scope.progress()
+		return total
+	}
 	return len(em.exercises)
 }
 
-// GetCompletedExerciseCount returns the number of completed exercises
+// GetCompletedExerciseCount returns the number of completed exercises, or of completed
+// required exercises in the active track
 func (em *ExerciseManager) GetCompletedExerciseCount() int {
+	if em.scope != nil {
+		completed, _ := em.scope.progress()
+		return completed
+	}
 	count := 0
 	for _, exercise := range em.exercises {
 		if exercise.Completed {
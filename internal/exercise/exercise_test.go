@@ -3,8 +3,12 @@ package exercise
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stonecharioteer/goforgo/internal/testutil"
 )
 
 func TestExerciseManager_ProgressTracking(t *testing.T) {
@@ -277,3 +281,354 @@ func TestProgress_Persistence(t *testing.T) {
 		t.Errorf("Expected current exercise 'next', got '%s'", em2.progress.CurrentExercise)
 	}
 }
+
+func TestExerciseManager_PackageExercise(t *testing.T) {
+	tempDir := t.TempDir()
+
+	categoryDir := filepath.Join(tempDir, "exercises", "02_packages")
+	pkgDir := filepath.Join(categoryDir, "layout")
+	if err := os.MkdirAll(filepath.Join(pkgDir, "internal", "greet"), 0755); err != nil {
+		t.Fatalf("Failed to create exercise directory: %v", err)
+	}
+
+	files := map[string]string{
+		"main.go":                 "package main\n\nfunc main() {}\n",
+		"helpers.go":              "package main\n",
+		"internal/greet/greet.go": "package greet\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pkgDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tomlContent := `[exercise]
+name = "layout"
+category = "02_packages"
+difficulty = 2
+dir = "layout"
+
+[description]
+title = "Layout"
+summary = "Package exercise"
+
+[validation]
+mode = "build"
+`
+	if err := os.WriteFile(filepath.Join(categoryDir, "layout.toml"), []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("Failed to write TOML file: %v", err)
+	}
+
+	em := NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	ex, err := em.GetExerciseByName("layout")
+	if err != nil {
+		t.Fatalf("Failed to get exercise: %v", err)
+	}
+
+	if !ex.IsPackage() || ex.WorkDir() != pkgDir {
+		t.Errorf("Expected package exercise rooted at %s, got %q", pkgDir, ex.Dir)
+	}
+	if ex.FilePath != filepath.Join(pkgDir, "main.go") {
+		t.Errorf("Expected main.go as primary file, got %s", ex.FilePath)
+	}
+	if len(ex.SourceFiles()) != 3 {
+		t.Errorf("Expected 3 source files, got %v", ex.SourceFiles())
+	}
+	if !ex.OwnsPath(filepath.Join(pkgDir, "internal", "greet", "new.go")) {
+		t.Error("Expected files under the package directory to belong to the exercise")
+	}
+	if ex.OwnsPath(filepath.Join(categoryDir, "other.go")) {
+		t.Error("Expected files outside the package directory not to belong to the exercise")
+	}
+	if want := filepath.Join(tempDir, "solutions", "02_packages", "testdata", "layout", "main.go"); ex.SolutionPath != want {
+		t.Errorf("Expected solution path %s, got %s", want, ex.SolutionPath)
+	}
+}
+
+func TestExerciseManager_ApplyToolchain(t *testing.T) {
+	em := &ExerciseManager{
+		exercises: []*Exercise{
+			{Info: ExerciseInfo{Name: "old", GoVersion: "1.16+"}},
+			{Info: ExerciseInfo{Name: "iterators", GoVersion: "1.23+"}},
+			{Info: ExerciseInfo{Name: "unversioned"}},
+		},
+	}
+
+	em.ApplyToolchain("go1.22.4")
+
+	if !em.exercises[0].IsAvailable() || !em.exercises[2].IsAvailable() {
+		t.Error("Expected exercises within the toolchain version to be available")
+	}
+	iterators := em.exercises[1]
+	if iterators.IsAvailable() {
+		t.Fatal("Expected 1.23+ exercise to be unavailable on go1.22.4")
+	}
+	if want := "requires Go 1.23 or newer (installed: go1.22.4)"; iterators.Unavailable != want {
+		t.Errorf("Unavailable = %q, want %q", iterators.Unavailable, want)
+	}
+
+	// Unavailable exercises are skipped when picking the next one
+	em.exercises[0].Completed = true
+	if next := em.GetNextExercise(); next == nil || next.Info.Name != "unversioned" {
+		t.Errorf("Expected next exercise to skip the unavailable one, got %v", next)
+	}
+}
+
+func TestExercise_ResolveHiddenTests(t *testing.T) {
+	ex := &Exercise{Validation: ExerciseValidation{HiddenTestFiles: []string{"stack_hidden_test.go"}}}
+	if err := ex.resolveHiddenTests("29_data_structures", "/ws/solutions/29_data_structures"); err != nil {
+		t.Fatalf("resolveHiddenTests returned error: %v", err)
+	}
+	want := HiddenTest{
+		Name:     "stack_hidden_test.go",
+		Embedded: "solutions/29_data_structures/stack_hidden_test.go",
+		Path:     filepath.Join("/ws/solutions/29_data_structures", "stack_hidden_test.go"),
+	}
+	if len(ex.HiddenTests) != 1 || ex.HiddenTests[0] != want {
+		t.Errorf("expected %+v, got %+v", want, ex.HiddenTests)
+	}
+
+	for _, bad := range []ExerciseValidation{
+		{HiddenTestFiles: []string{"stack.go"}},
+		{HiddenTestFiles: []string{"../other/stack_test.go"}},
+		{HiddenTestFiles: []string{"stack_test.go"}, HiddenDetail: "everything"},
+	} {
+		ex := &Exercise{Validation: bad}
+		if err := ex.resolveHiddenTests("cat", "/ws/solutions/cat"); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestExerciseValidation_StaticCheckList(t *testing.T) {
+	var cfg struct{ Validation ExerciseValidation }
+	_, err := toml.Decode(`[validation]
+mode = "run"
+static_check = "has_line_comment"
+static_match = "any"
+static_checks = [
+  { name = "must_call", args = { func = "errors.Is" } },
+  { name = "forbid_import", args = { path = "fmt" } },
+]
+`, &cfg)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	got := cfg.Validation.StaticCheckList()
+	if len(got) != 3 || got[0].Name != "has_line_comment" || got[1].Args["func"] != "errors.Is" || got[2].Args["path"] != "fmt" {
+		t.Errorf("unexpected static checks: %+v", got)
+	}
+	if cfg.Validation.StaticMatch != "any" {
+		t.Errorf("expected static_match any, got %q", cfg.Validation.StaticMatch)
+	}
+}
+
+func TestExercise_ResolveAnalyzers(t *testing.T) {
+	ex := &Exercise{Validation: ExerciseValidation{Analyzers: []AnalyzerSpec{
+		{Path: "tools/idioms"},
+		{Module: "golang.org/x/tools/go/analysis/passes/nilness/cmd/nilness@v0.34.0", Protocol: "json"},
+	}}}
+	if err := ex.resolveAnalyzers("/ws/exercises/52_errors"); err != nil {
+		t.Fatalf("resolveAnalyzers returned error: %v", err)
+	}
+	if got := ex.Validation.Analyzers[0].Path; got != filepath.Join("/ws/exercises/52_errors", "tools/idioms") {
+		t.Errorf("expected the path to be resolved against the TOML directory, got %s", got)
+	}
+	if got := ex.Validation.Analyzers[1].DisplayName(); got != "nilness" {
+		t.Errorf("expected the module's last element as name, got %s", got)
+	}
+
+	for _, bad := range []AnalyzerSpec{
+		{},
+		{Path: "idioms", Module: "example.com/idioms"},
+		{Path: "idioms", Protocol: "sarif"},
+	} {
+		ex := &Exercise{Validation: ExerciseValidation{Analyzers: []AnalyzerSpec{bad}}}
+		if err := ex.resolveAnalyzers("/ws"); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestQualityConfig_Validate(t *testing.T) {
+	if err := (QualityConfig{Gofmt: "block", Vet: "warn", Analyzers: "off"}).validate(); err != nil {
+		t.Errorf("expected valid levels to pass, got %v", err)
+	}
+	if err := (QualityConfig{Vet: "error"}).validate(); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestExerciseManager_UnknownSandbox(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "sandbox = \"jail\"\n")
+
+	err := NewExerciseManager(tempDir).LoadExercises()
+	if err == nil || !strings.Contains(err.Error(), `unknown sandbox "jail"`) {
+		t.Errorf("Expected an unknown sandbox to fail loading, got %v", err)
+	}
+}
+
+func TestExerciseManager_InvalidSpeedup(t *testing.T) {
+	tests := map[string]string{
+		"negative":    "faster_than = \"BenchmarkPlus\"\nmin_speedup = -2.0\n",
+		"no baseline": "min_speedup = 2.0\n",
+	}
+	for name, threshold := range tests {
+		tempDir := t.TempDir()
+		writeTestExercise(t, tempDir, "01_basics", "hello", "\n[[validation.bench.thresholds]]\nbenchmark = \"BenchmarkJoin\"\n"+threshold)
+		if err := NewExerciseManager(tempDir).LoadExercises(); err == nil || !strings.Contains(err.Error(), "min_speedup") {
+			t.Errorf("%s: expected loading to fail, got %v", name, err)
+		}
+	}
+}
+
+// writeTestExercise writes a build-mode exercise with the given extra TOML under root/exercises
+func writeTestExercise(t *testing.T, root, category, name, extra string) {
+	t.Helper()
+	testutil.WriteExercise(t, root, testutil.Exercise{Category: category, Name: name, Extra: extra})
+}
+
+func TestExerciseManager_Metadata(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", `
+[metadata]
+tags = ["basics", "Printing"]
+related_exercises = ["02_channels/pipes.go", "02_channels/pipes"]
+`)
+	writeTestExercise(t, tempDir, "02_channels", "pipes", `
+[metadata]
+tags = ["concurrency"]
+`)
+
+	em := NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	hello, _ := em.GetExerciseByName("hello")
+	pipes, _ := em.GetExerciseByName("pipes")
+	if !hello.HasTag("printing") || hello.HasTag("concurrency") {
+		t.Errorf("Unexpected tag matches for tags %v", hello.Metadata.Tags)
+	}
+	if len(hello.Related) != 2 || hello.Related[0] != pipes || hello.Related[1] != pipes {
+		t.Errorf("Expected both related paths to resolve to pipes, got %v", hello.Related)
+	}
+
+	writeTestExercise(t, tempDir, "02_channels", "select", `
+[metadata]
+related_exercises = ["02_channels/missing.go"]
+`)
+	// Workspaces from older versions may link to exercises that moved; that's no reason to stop
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Expected a missing related exercise to be skipped, got %v", err)
+	}
+	sel, _ := em.GetExerciseByName("select")
+	if warnings := em.Warnings(); len(sel.Related) != 0 || len(warnings) != 1 || !strings.Contains(warnings[0], `"02_channels/missing.go" not found`) {
+		t.Errorf("Expected the missing link skipped with a warning, got %v, %v", sel.Related, warnings)
+	}
+
+	em.Strict = true
+	err := em.LoadExercises()
+	if err == nil || !strings.Contains(err.Error(), `"02_channels/missing.go" not found`) {
+		t.Errorf("Expected missing related exercise error, got %v", err)
+	}
+}
+
+// TestShippedExerciseLinks loads goforgo's own exercises strictly, so a related exercise
+// or prerequisite that doesn't exist fails CI instead of only warning learners
+func TestShippedExerciseLinks(t *testing.T) {
+	t.Setenv("GOFORGO_PROGRESS_STORE", "")
+	em := NewExerciseManager(filepath.Join("..", ".."))
+	em.Quiet = true
+	em.Strict = true
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load the shipped exercises: %v", err)
+	}
+
+	tracks, err := em.LoadTracks()
+	if err != nil {
+		t.Fatalf("Failed to load the shipped tracks: %v", err)
+	}
+	for _, track := range tracks {
+		if _, _, err := em.TrackProgress(track); err != nil {
+			t.Errorf("Shipped track %s doesn't resolve: %v", track.Name, err)
+		}
+	}
+}
+
+func TestExerciseManager_Prerequisites(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+	writeTestExercise(t, tempDir, "02_channels", "pipes", "")
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "03_kafka", Name: "producers", Fields: "prerequisites = [\"02_channels/pipes.go\"]\n"})
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "03_kafka", Name: "consumers", Fields: "prerequisites = [\"03_kafka/producers\"]\n"})
+
+	em := NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	producers, _ := em.GetExerciseByName("producers")
+	consumers, _ := em.GetExerciseByName("consumers")
+	if producers.IsUnlocked() || consumers.IsUnlocked() {
+		t.Error("Expected kafka exercises to be locked before their prerequisites are done")
+	}
+
+	targets, err := em.FindTarget("kafka")
+	if err != nil {
+		t.Fatalf("FindTarget failed: %v", err)
+	}
+	var names []string
+	for _, ex := range em.PathTo(targets) {
+		names = append(names, ex.Info.Name)
+	}
+	if got := strings.Join(names, ","); got != "pipes,producers,consumers" {
+		t.Errorf("Expected path pipes,producers,consumers, got %s", got)
+	}
+
+	if next := em.GetNextExercise(); next.Info.Name != "hello" {
+		t.Errorf("Expected hello before anything is done, got %s", next.Info.Name)
+	}
+	if err := em.MarkExerciseCompleted("pipes"); err != nil {
+		t.Fatalf("Failed to mark exercise completed: %v", err)
+	}
+	// Finishing pipes unlocks producers, which is recommended ahead of the basics
+	if next := em.GetNextExercise(); next != producers {
+		t.Errorf("Expected producers after pipes, got %s", next.Info.Name)
+	}
+	if len(em.PathTo(targets)) != 2 {
+		t.Errorf("Expected completed exercises to drop out of the path, got %v", em.PathTo(targets))
+	}
+
+	// Moving on to another topic continues there instead of going back to producers
+	if err := em.MarkExerciseCompleted("hello"); err != nil {
+		t.Fatalf("Failed to mark exercise completed: %v", err)
+	}
+	if next := em.GetNextExercise(); next.Info.Name != "variables" {
+		t.Errorf("Expected variables after hello, got %s", next.Info.Name)
+	}
+	if err := em.MarkExerciseCompleted("producers"); err != nil {
+		t.Fatalf("Failed to mark exercise completed: %v", err)
+	}
+	if next := em.GetNextExercise(); next != consumers {
+		t.Errorf("Expected consumers after producers, got %s", next.Info.Name)
+	}
+
+	if _, err := em.FindTarget("nothing"); err == nil {
+		t.Error("Expected an error for an unknown target")
+	}
+
+	// A cycle is rejected at load time
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "02_channels", Name: "pipes", Fields: "prerequisites = [\"03_kafka/consumers.go\"]\n"})
+	err = em.LoadExercises()
+	if err == nil || !strings.Contains(err.Error(), "prerequisite cycle: 02_channels/pipes -> 03_kafka/consumers -> 03_kafka/producers -> 02_channels/pipes") {
+		t.Errorf("Expected a prerequisite cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package exercise
+
+import "os"
+
+// lockFile is a no-op where goforgo has no file locking; saves are still atomic
+func lockFile(file *os.File) error {
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
+
+func syncDir(dir string) error {
+	return nil
+}
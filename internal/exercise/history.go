@@ -0,0 +1,192 @@
+package exercise
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProgressVersion is the schema version of the progress file this goforgo writes.
+// Version 1 files (without a version key) only recorded completion.
+const ProgressVersion = 2
+
+// Failure kinds recorded in an exercise's history, by the step that failed
+const (
+	FailureSetup   = "setup"   // Unavailable exercise or missing required files
+	FailureBuild   = "build"   // The code didn't compile
+	FailureTest    = "test"    // Tests, benchmarks, fuzzing or mutation testing failed
+	FailureOutput  = "output"  // The program ran but printed the wrong output
+	FailureStatic  = "static"  // A static check or external analyzer failed
+	FailureQuality = "quality" // A blocking quality gate finding
+	FailureTodo    = "todo"    // Blocking TODO comments remain
+	FailureError   = "error"   // goforgo couldn't run the exercise
+)
+
+// maxFailureKinds caps the failure sequence kept per exercise, dropping the oldest
+const maxFailureKinds = 50
+
+// ExerciseHistory is what the progress file remembers about one exercise
+type ExerciseHistory struct {
+	FirstSeen      time.Time     `toml:"first_seen"`
+	LastRun        time.Time     `toml:"last_run,omitempty"`
+	CompletedAt    time.Time     `toml:"completed_at,omitempty"`
+	Runs           int           `toml:"runs"`
+	RunsToComplete int           `toml:"runs_to_complete,omitempty"` // Runs up to and including the one that completed it
+	Failures       int           `toml:"failures"`
+	HintsViewed    int           `toml:"hints_viewed"`
+	TimeSpent      time.Duration `toml:"time_spent"`              // With the exercise open in watch mode
+	FailureKinds   []string      `toml:"failure_kinds,omitempty"` // Most recent last, e.g. ["build", "build", "test"]
+}
+
+// DailyActivity is what the learner did on one day, across all exercises
+type DailyActivity struct {
+	Runs        int           `toml:"runs"`
+	Failures    int           `toml:"failures"`
+	Completions int           `toml:"completions"`
+	TimeSpent   time.Duration `toml:"time_spent"`
+}
+
+// ActivityDateLayout is the layout of the dates keying Progress.Activity
+const ActivityDateLayout = "2006-01-02"
+
+// newProgress returns empty progress at the current schema version
+func newProgress() *Progress {
+	return &Progress{
+		Version:            ProgressVersion,
+		CompletedExercises: make(map[string]bool),
+		History:            make(map[string]*ExerciseHistory),
+		Activity:           make(map[string]*DailyActivity),
+	}
+}
+
+// migrateProgress upgrades progress decoded from an older progress file in place
+func migrateProgress(p *Progress) error {
+	if p.CompletedExercises == nil {
+		p.CompletedExercises = make(map[string]bool)
+	}
+	if p.History == nil {
+		p.History = make(map[string]*ExerciseHistory)
+	}
+	if p.Activity == nil {
+		p.Activity = make(map[string]*DailyActivity)
+	}
+
+	switch {
+	case p.Version > ProgressVersion:
+		return fmt.Errorf("progress file has schema version %d, but this goforgo only knows up to %d; upgrade goforgo", p.Version, ProgressVersion)
+	case p.Version <= 1:
+		// Version 1 only kept completion flags. Completed exercises get a history entry
+		// without timestamps, since when they were done was never recorded.
+		for name, done := range p.CompletedExercises {
+			if done && p.History[name] == nil {
+				p.History[name] = &ExerciseHistory{}
+			}
+		}
+		p.Version = 2
+	}
+	return nil
+}
+
+// history returns the history entry for an exercise, starting one if it has none
+func (em *ExerciseManager) history(name string) *ExerciseHistory {
+	h := em.progress.History[name]
+	if h == nil {
+		h = &ExerciseHistory{FirstSeen: time.Now()}
+		em.progress.History[name] = h
+	}
+	if h.FirstSeen.IsZero() {
+		h.FirstSeen = time.Now()
+	}
+	return h
+}
+
+// activity returns today's activity entry, starting one if needed
+func (em *ExerciseManager) activity() *DailyActivity {
+	day := time.Now().Format(ActivityDateLayout)
+	a := em.progress.Activity[day]
+	if a == nil {
+		a = &DailyActivity{}
+		em.progress.Activity[day] = a
+	}
+	return a
+}
+
+// Activity returns a copy of the recorded daily activity, keyed by ActivityDateLayout dates
+func (em *ExerciseManager) Activity() map[string]DailyActivity {
+	activity := make(map[string]DailyActivity, len(em.progress.Activity))
+	for day, a := range em.progress.Activity {
+		activity[day] = *a
+	}
+	return activity
+}
+
+// History returns a copy of what's been recorded about an exercise, and whether
+// anything has been
+func (em *ExerciseManager) History(name string) (ExerciseHistory, bool) {
+	h, ok := em.progress.History[name]
+	if !ok {
+		return ExerciseHistory{}, false
+	}
+	copied := *h
+	copied.FailureKinds = append([]string(nil), h.FailureKinds...)
+	return copied, true
+}
+
+// MarkSeen records that the learner has opened an exercise
+func (em *ExerciseManager) MarkSeen(exerciseName string) error {
+	if _, ok := em.progress.History[exerciseName]; ok {
+		return nil
+	}
+	em.history(exerciseName)
+	return em.saveProgress()
+}
+
+// RecordRun records one validation run of an exercise. failureKind is one of the
+// Failure constants and is ignored when the run succeeded.
+func (em *ExerciseManager) RecordRun(exerciseName string, success bool, failureKind string) error {
+	h := em.history(exerciseName)
+	h.Runs++
+	h.LastRun = time.Now()
+	day := em.activity()
+	day.Runs++
+	if !success {
+		day.Failures++
+		h.Failures++
+		h.FailureKinds = append(h.FailureKinds, failureKind)
+		if len(h.FailureKinds) > maxFailureKinds {
+			h.FailureKinds = h.FailureKinds[len(h.FailureKinds)-maxFailureKinds:]
+		}
+	}
+	em.syncHistory(exerciseName)
+	return em.saveProgress()
+}
+
+// RecordHintViewed records that the learner looked at a hint for an exercise
+func (em *ExerciseManager) RecordHintViewed(exerciseName string) error {
+	em.history(exerciseName).HintsViewed++
+	return em.saveProgress()
+}
+
+// AddTimeSpent adds time the learner spent with an exercise open
+func (em *ExerciseManager) AddTimeSpent(exerciseName string, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	em.history(exerciseName).TimeSpent += d
+	em.activity().TimeSpent += d
+	return em.saveProgress()
+}
+
+// syncHistory copies the persisted attempt count and last run time onto the loaded
+// exercises with the given name, which GetHint escalates on
+func (em *ExerciseManager) syncHistory(exerciseName string) {
+	h := em.progress.History[exerciseName]
+	if h == nil {
+		return
+	}
+	for _, ex := range em.exercises {
+		if ex.Info.Name == exerciseName {
+			ex.Attempts = h.Failures
+			ex.LastAttempt = h.LastRun
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package exercise
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExerciseManager_History(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "\n[hints]\nlevel_1 = \"one\"\nlevel_2 = \"two\"\nlevel_3 = \"three\"\n")
+
+	em := NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	for _, kind := range []string{FailureBuild, FailureBuild, FailureTest} {
+		if err := em.RecordRun("hello", false, kind); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+	if err := em.RecordHintViewed("hello"); err != nil {
+		t.Fatalf("RecordHintViewed failed: %v", err)
+	}
+	if err := em.AddTimeSpent("hello", 90*time.Second); err != nil {
+		t.Fatalf("AddTimeSpent failed: %v", err)
+	}
+	if err := em.RecordRun("hello", true, ""); err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	if err := em.MarkExerciseCompleted("hello"); err != nil {
+		t.Fatalf("MarkExerciseCompleted failed: %v", err)
+	}
+
+	// Everything survives a new session, and hints escalate on the persisted failures
+	em2 := NewExerciseManager(tempDir)
+	if err := em2.LoadExercises(); err != nil {
+		t.Fatalf("Failed to reload exercises: %v", err)
+	}
+	h, ok := em2.History("hello")
+	if !ok {
+		t.Fatal("Expected history for hello")
+	}
+	if h.Runs != 4 || h.Failures != 3 || h.HintsViewed != 1 || h.TimeSpent != 90*time.Second {
+		t.Errorf("Unexpected history: %+v", h)
+	}
+	if strings.Join(h.FailureKinds, ",") != "build,build,test" {
+		t.Errorf("Unexpected failure kinds: %v", h.FailureKinds)
+	}
+	if h.FirstSeen.IsZero() || h.LastRun.IsZero() || h.CompletedAt.IsZero() {
+		t.Errorf("Expected first seen, last run and completed timestamps, got %+v", h)
+	}
+	ex, _ := em2.GetExerciseByName("hello")
+	if ex.Attempts != 3 || ex.GetHint() != "two" {
+		t.Errorf("Expected 3 persisted attempts and the second hint, got %d and %q", ex.Attempts, ex.GetHint())
+	}
+}
+
+func TestExerciseManager_ProgressMigration(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+
+	// A version 1 file: completion flags only, no version key
+	em := NewExerciseManager(tempDir)
+	v1 := "current_exercise = \"variables\"\nlast_updated = 2025-01-02T03:04:05Z\n\n[completed_exercises]\nhello = true\n"
+	if err := os.WriteFile(em.ProgressPath, []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	em = NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+	if em.progress.Version != ProgressVersion {
+		t.Errorf("Expected migration to version %d, got %d", ProgressVersion, em.progress.Version)
+	}
+	if _, ok := em.History("hello"); !ok || !em.GetCompletedExercises()["hello"] {
+		t.Error("Expected the completed exercise to keep its completion and get a history entry")
+	}
+	if err := em.RecordRun("variables", false, FailureOutput); err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	data, _ := os.ReadFile(em.ProgressPath)
+	if !strings.Contains(string(data), "version = 2") {
+		t.Errorf("Expected the migrated file to be saved at version 2:\n%s", data)
+	}
+
+	// Files from a newer goforgo are read but never overwritten
+	newer := strings.Replace(string(data), "version = 2", "version = 99", 1)
+	if err := os.WriteFile(em.ProgressPath, []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+	em = NewExerciseManager(tempDir)
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+	if err := em.RecordRun("variables", true, ""); err == nil {
+		t.Error("Expected saving over a newer progress file to fail")
+	}
+	if data, _ := os.ReadFile(em.ProgressPath); string(data) != newer {
+		t.Error("Expected the newer progress file to be left untouched")
+	}
+}
@@ -0,0 +1,370 @@
+package exercise
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// loadProgress reads the progress from the store and returns why it mustn't be saved
+// over, if it mustn't. Progress that can't be read is left untouched in the store:
+// progress starts empty in memory and nothing is saved over it.
+func (em *ExerciseManager) loadProgress() error {
+	em.progress, em.progressBase, em.progressRev, em.progressErr = newProgress(), newProgress(), "", nil
+
+	stored, err := em.store.Load()
+	if err != nil {
+		em.progressErr = err
+		return err
+	}
+	em.progressRev = stored.Revision
+
+	em.progressErr = stored.Err
+	if stored.Progress != nil {
+		em.progress = stored.Progress
+		em.progressBase = cloneProgress(stored.Progress)
+	}
+	return em.progressErr
+}
+
+// parseProgress decodes and migrates the content of a progress file. It returns a nil
+// Progress when the content doesn't decode.
+func parseProgress(data []byte) (*Progress, error) {
+	loaded := &Progress{}
+	if _, err := toml.Decode(string(data), loaded); err != nil {
+		return nil, err
+	}
+
+	// Older files are upgraded in memory and rewritten on the next save. Files from a
+	// newer goforgo are kept readable but never overwritten, so nothing is lost.
+	return loaded, migrateProgress(loaded)
+}
+
+// saveProgress writes the progress to the store. Whatever another goforgo process
+// saved since this one last read or wrote the store is merged in rather than
+// overwritten.
+func (em *ExerciseManager) saveProgress() error {
+	if em.progressErr != nil {
+		return em.progressErr
+	}
+
+	var saved *Progress
+	var unwritable error
+	var unwritableRev string
+	rev, err := em.store.Update(func(current StoredProgress) (*Progress, error) {
+		if current.Err != nil {
+			unwritable, unwritableRev = current.Err, current.Revision
+			return nil, current.Err
+		}
+		saved = em.progress
+		if current.Revision != em.progressRev {
+			theirs := current.Progress
+			if theirs == nil {
+				theirs = newProgress()
+			}
+			saved = mergeProgress(em.progressBase, em.progress, theirs)
+		}
+		saved.LastUpdated = time.Now()
+		return saved, nil
+	})
+	if unwritable != nil {
+		em.progressErr, em.progressRev = unwritable, unwritableRev
+	}
+	if err != nil {
+		return err
+	}
+
+	merged := saved != em.progress
+	em.progress = saved
+	em.progressBase = cloneProgress(saved)
+	em.progressRev = rev
+	if merged {
+		em.UpdateExerciseProgress()
+	}
+	return nil
+}
+
+// ReloadProgress re-reads the progress if another process changed it since this
+// manager last read or wrote it, and reports whether it did, along with what's wrong
+// with the new progress, if anything: a corrupt file or an active track that doesn't
+// resolve. Watch mode calls it when the progress file changes, so a 'goforgo sync' in
+// another terminal shows up straight away.
+func (em *ExerciseManager) ReloadProgress() (bool, error) {
+	rev, err := em.store.Revision()
+	if err != nil || rev == em.progressRev {
+		return false, nil
+	}
+
+	loadErr := em.loadProgress()
+	em.UpdateExerciseProgress()
+	return true, errors.Join(loadErr, em.applyActiveTrack())
+}
+
+// ExportProgress returns the current progress, history included, encoded as a progress file
+func (em *ExerciseManager) ExportProgress() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(em.progress); err != nil {
+		return nil, fmt.Errorf("failed to encode progress: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ProgressImport describes how imported progress was mapped onto the loaded exercises
+type ProgressImport struct {
+	Renamed map[string]string // Exercise names in the import, to the exercise now holding their progress
+	Removed []string          // Exercises that no longer exist, whose progress was dropped
+}
+
+// ImportProgress merges progress exported by another goforgo, possibly an older version,
+// into this manager's progress and saves it. Exercises are matched by name, then path,
+// then aliases, so renamed exercises keep their progress; paths maps exercise names to
+// their paths in the exporting goforgo, for aliases naming a path. Completions are kept
+// and each counter takes the larger of the local and imported value, so importing the
+// same archive again, or after a partial restore, counts nothing twice; replace
+// discards the local progress instead.
+func (em *ExerciseManager) ImportProgress(data []byte, paths map[string]string, replace bool) (*ProgressImport, error) {
+	imported, err := parseProgress(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid progress in import: %w", err)
+	}
+
+	result := &ProgressImport{Renamed: make(map[string]string)}
+	removed := make(map[string]bool)
+	resolve := func(name string) (string, bool) {
+		ex := em.ResolveExercise(name)
+		if ex == nil && paths[name] != "" {
+			ex = em.ResolveExercise(paths[name])
+		}
+		if ex == nil {
+			removed[name] = true
+			return "", false
+		}
+		if ex.Info.Name != name {
+			result.Renamed[name] = ex.Info.Name
+		}
+		return ex.Info.Name, true
+	}
+
+	remapped := newProgress()
+	for name, done := range imported.CompletedExercises {
+		if current, ok := resolve(name); ok && done {
+			remapped.CompletedExercises[current] = true
+		}
+	}
+	for name, h := range imported.History {
+		current, ok := resolve(name)
+		if !ok {
+			continue
+		}
+		// Two old exercises merged into one add up
+		target := remapped.History[current]
+		if target == nil {
+			target = &ExerciseHistory{}
+			remapped.History[current] = target
+		}
+		mergeHistory(target, ExerciseHistory{}, *h)
+	}
+	if imported.CurrentExercise != "" {
+		remapped.CurrentExercise, _ = resolve(imported.CurrentExercise)
+	}
+	if imported.LastCompleted != "" {
+		remapped.LastCompleted, _ = resolve(imported.LastCompleted)
+	}
+	remapped.ActiveTrack = imported.ActiveTrack
+	remapped.Activity = cloneProgress(imported).Activity
+
+	for name := range removed {
+		result.Removed = append(result.Removed, name)
+	}
+	sort.Strings(result.Removed)
+
+	if replace {
+		em.progress = remapped
+	} else {
+		em.progress = maxProgress(em.progress, remapped)
+	}
+	em.UpdateExerciseProgress()
+	em.warn(em.applyActiveTrack())
+	return result, em.saveProgress()
+}
+
+// cloneProgress deep-copies progress
+func cloneProgress(p *Progress) *Progress {
+	c := *p
+	c.CompletedExercises = maps.Clone(p.CompletedExercises)
+	c.History = make(map[string]*ExerciseHistory, len(p.History))
+	for name, h := range p.History {
+		copied := *h
+		copied.FailureKinds = slices.Clone(h.FailureKinds)
+		c.History[name] = &copied
+	}
+	c.Activity = make(map[string]*DailyActivity, len(p.Activity))
+	for day, a := range p.Activity {
+		copied := *a
+		c.Activity[day] = &copied
+	}
+	return &c
+}
+
+// mergeProgress applies the changes made in mine since base on top of theirs, the
+// progress another process saved in the meantime. Counters add up, first and last
+// timestamps widen, and completion flags and settings changed in mine win.
+func mergeProgress(base, mine, theirs *Progress) *Progress {
+	merged := cloneProgress(theirs)
+
+	for name := range mergedKeys(base.CompletedExercises, mine.CompletedExercises) {
+		if mine.CompletedExercises[name] == base.CompletedExercises[name] {
+			continue
+		}
+		if mine.CompletedExercises[name] {
+			merged.CompletedExercises[name] = true
+		} else {
+			delete(merged.CompletedExercises, name)
+		}
+	}
+	if mine.CurrentExercise != base.CurrentExercise {
+		merged.CurrentExercise = mine.CurrentExercise
+	}
+	if mine.LastCompleted != base.LastCompleted {
+		merged.LastCompleted = mine.LastCompleted
+	}
+	if mine.ActiveTrack != base.ActiveTrack {
+		merged.ActiveTrack = mine.ActiveTrack
+	}
+
+	for name, h := range mine.History {
+		old := ExerciseHistory{}
+		if b := base.History[name]; b != nil {
+			old = *b
+		}
+		target := merged.History[name]
+		if target == nil {
+			target = &ExerciseHistory{}
+			merged.History[name] = target
+		}
+		mergeHistory(target, old, *h)
+	}
+
+	for day, a := range mine.Activity {
+		old := DailyActivity{}
+		if b := base.Activity[day]; b != nil {
+			old = *b
+		}
+		target := merged.Activity[day]
+		if target == nil {
+			target = &DailyActivity{}
+			merged.Activity[day] = target
+		}
+		target.Runs += a.Runs - old.Runs
+		target.Failures += a.Failures - old.Failures
+		target.Completions += a.Completions - old.Completions
+		target.TimeSpent += a.TimeSpent - old.TimeSpent
+	}
+	return merged
+}
+
+// maxProgress combines local progress with imported progress that may overlap it:
+// completions are kept from both, and each counter and activity total is the larger of
+// the two, so merging the same progress again changes nothing
+func maxProgress(local, imported *Progress) *Progress {
+	merged := cloneProgress(local)
+	for name, done := range imported.CompletedExercises {
+		if done {
+			merged.CompletedExercises[name] = true
+		}
+	}
+	if merged.CurrentExercise == "" {
+		merged.CurrentExercise = imported.CurrentExercise
+	}
+	if merged.LastCompleted == "" {
+		merged.LastCompleted = imported.LastCompleted
+	}
+	if merged.ActiveTrack == "" {
+		merged.ActiveTrack = imported.ActiveTrack
+	}
+
+	for name, h := range imported.History {
+		target := merged.History[name]
+		if target == nil {
+			copied := *h
+			copied.FailureKinds = slices.Clone(h.FailureKinds)
+			merged.History[name] = &copied
+			continue
+		}
+		if h.Failures > target.Failures {
+			target.FailureKinds = slices.Clone(h.FailureKinds)
+		}
+		target.Runs = max(target.Runs, h.Runs)
+		target.Failures = max(target.Failures, h.Failures)
+		target.HintsViewed = max(target.HintsViewed, h.HintsViewed)
+		target.TimeSpent = max(target.TimeSpent, h.TimeSpent)
+		if target.FirstSeen.IsZero() || !h.FirstSeen.IsZero() && h.FirstSeen.Before(target.FirstSeen) {
+			target.FirstSeen = h.FirstSeen
+		}
+		if h.LastRun.After(target.LastRun) {
+			target.LastRun = h.LastRun
+		}
+		if target.CompletedAt.IsZero() {
+			target.CompletedAt = h.CompletedAt
+			target.RunsToComplete = h.RunsToComplete
+		}
+	}
+
+	for day, a := range imported.Activity {
+		target := merged.Activity[day]
+		if target == nil {
+			copied := *a
+			merged.Activity[day] = &copied
+			continue
+		}
+		target.Runs = max(target.Runs, a.Runs)
+		target.Failures = max(target.Failures, a.Failures)
+		target.Completions = max(target.Completions, a.Completions)
+		target.TimeSpent = max(target.TimeSpent, a.TimeSpent)
+	}
+	return merged
+}
+
+// mergeHistory adds what happened to an exercise between base and mine to target
+func mergeHistory(target *ExerciseHistory, base, mine ExerciseHistory) {
+	target.Runs += mine.Runs - base.Runs
+	target.Failures += mine.Failures - base.Failures
+	target.HintsViewed += mine.HintsViewed - base.HintsViewed
+	target.TimeSpent += mine.TimeSpent - base.TimeSpent
+
+	if target.FirstSeen.IsZero() || !mine.FirstSeen.IsZero() && mine.FirstSeen.Before(target.FirstSeen) {
+		target.FirstSeen = mine.FirstSeen
+	}
+	if mine.LastRun.After(target.LastRun) {
+		target.LastRun = mine.LastRun
+	}
+	if !mine.CompletedAt.Equal(base.CompletedAt) {
+		target.CompletedAt = mine.CompletedAt
+		target.RunsToComplete = mine.RunsToComplete
+	}
+
+	if added := mine.Failures - base.Failures; added > 0 {
+		target.FailureKinds = append(target.FailureKinds, mine.FailureKinds[max(0, len(mine.FailureKinds)-added):]...)
+		if len(target.FailureKinds) > maxFailureKinds {
+			target.FailureKinds = target.FailureKinds[len(target.FailureKinds)-maxFailureKinds:]
+		}
+	}
+}
+
+// mergedKeys returns the union of two maps' keys
+func mergedKeys(a, b map[string]bool) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
@@ -0,0 +1,244 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stonecharioteer/goforgo/internal/testutil"
+)
+
+// loadTestManager loads the exercises under root in a fresh manager, as a new goforgo process would
+func loadTestManager(t *testing.T, root string) *ExerciseManager {
+	t.Helper()
+	em := NewExerciseManager(root)
+	em.Quiet = true
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+	return em
+}
+
+func TestExerciseManager_CorruptProgress(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+
+	progressPath := filepath.Join(tempDir, ".goforgo-progress.toml")
+	corrupt := "version = 2\n[completed_exercises\nhello = true\n"
+	if err := os.WriteFile(progressPath, []byte(corrupt), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	em := loadTestManager(t, tempDir)
+	if warnings := em.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "corrupt") {
+		t.Errorf("Expected a warning about the corrupt progress file, got %v", warnings)
+	}
+	if err := em.MarkExerciseCompleted("hello"); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("Expected saving over a corrupt progress file to fail, got %v", err)
+	}
+	if data, _ := os.ReadFile(progressPath); string(data) != corrupt {
+		t.Error("Expected the corrupt progress file to be left untouched")
+	}
+
+	// One backup, however many times the file is loaded
+	loadTestManager(t, tempDir)
+	backups, _ := filepath.Glob(progressPath + ".corrupt-*")
+	if len(backups) != 1 {
+		t.Fatalf("Expected one backup, got %v", backups)
+	}
+	if data, _ := os.ReadFile(backups[0]); string(data) != corrupt {
+		t.Error("Expected the backup to hold the corrupt content")
+	}
+
+	// Once the file is fixed, progress is saved again
+	if err := os.Remove(progressPath); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ := em.ReloadProgress(); !changed {
+		t.Fatal("Expected the removed file to be noticed")
+	}
+	if err := em.MarkExerciseCompleted("hello"); err != nil {
+		t.Errorf("Expected saving to work once the corrupt file is gone, got %v", err)
+	}
+}
+
+func TestExerciseManager_SaveProgressAtomically(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+
+	em := loadTestManager(t, tempDir)
+	for i := 0; i < 3; i++ {
+		if err := em.RecordRun("hello", false, FailureBuild); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+
+	// Nothing but the progress file and its lock is left behind
+	leftovers, _ := filepath.Glob(filepath.Join(tempDir, ".goforgo-progress*"))
+	for _, path := range leftovers {
+		if base := filepath.Base(path); base != ".goforgo-progress.toml" && base != ".goforgo-progress.toml.lock" {
+			t.Errorf("Unexpected file left behind: %s", base)
+		}
+	}
+	if h, _ := loadTestManager(t, tempDir).History("hello"); h.Runs != 3 {
+		t.Errorf("Expected 3 saved runs, got %d", h.Runs)
+	}
+}
+
+func TestExerciseManager_ConcurrentProgress(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+
+	// Two processes with the same file open: neither loses the other's changes
+	tui := loadTestManager(t, tempDir)
+	cli := loadTestManager(t, tempDir)
+	if err := cli.RecordRun("hello", true, ""); err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	if err := cli.MarkExerciseCompleted("hello"); err != nil {
+		t.Fatalf("MarkExerciseCompleted failed: %v", err)
+	}
+	if err := tui.RecordRun("hello", false, FailureTest); err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	if err := tui.RecordRun("variables", false, FailureBuild); err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+
+	after := loadTestManager(t, tempDir)
+	h, _ := after.History("hello")
+	if h.Runs != 2 || h.Failures != 1 || h.CompletedAt.IsZero() || !after.GetCompletedExercises()["hello"] {
+		t.Errorf("Expected both processes' runs and the completion of hello, got %+v", h)
+	}
+	if h, _ := after.History("variables"); h.Runs != 1 {
+		t.Errorf("Expected one run of variables, got %d", h.Runs)
+	}
+	if ex, _ := tui.GetExerciseByName("hello"); !ex.Completed {
+		t.Error("Expected the merged completion to show on the loaded exercises")
+	}
+
+	// Many writers at once: every run is counted
+	const writers, runs = 4, 10
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		em := loadTestManager(t, tempDir)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < runs; j++ {
+				if err := em.RecordRun("variables", true, ""); err != nil {
+					t.Errorf("RecordRun failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if h, _ := loadTestManager(t, tempDir).History("variables"); h.Runs != 1+writers*runs {
+		t.Errorf("Expected %d runs of variables, got %d", 1+writers*runs, h.Runs)
+	}
+}
+
+func TestExerciseManager_ReloadProgress(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+
+	tui := loadTestManager(t, tempDir)
+	if changed, _ := tui.ReloadProgress(); changed {
+		t.Error("Expected no reload before anything changed")
+	}
+
+	cli := loadTestManager(t, tempDir)
+	if err := cli.MarkExerciseCompleted("hello"); err != nil {
+		t.Fatalf("MarkExerciseCompleted failed: %v", err)
+	}
+	if changed, _ := tui.ReloadProgress(); !changed {
+		t.Fatal("Expected a reload after another manager saved")
+	}
+	if ex, _ := tui.GetExerciseByName("hello"); !ex.Completed {
+		t.Error("Expected the reloaded completion to show on the loaded exercises")
+	}
+
+	// A manager's own saves don't count as changes
+	if err := tui.RecordHintViewed("hello"); err != nil {
+		t.Fatalf("RecordHintViewed failed: %v", err)
+	}
+	if changed, _ := tui.ReloadProgress(); changed {
+		t.Error("Expected no reload after the manager's own save")
+	}
+
+	// A corrupt file written by another process is returned, not printed over the TUI
+	if err := os.WriteFile(filepath.Join(tempDir, ".goforgo-progress.toml"), []byte("[completed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := tui.ReloadProgress(); !changed || err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("Expected the corrupt progress to be reported, got %v, %v", changed, err)
+	}
+}
+
+func TestExerciseManager_ImportProgress(t *testing.T) {
+	oldDir := t.TempDir()
+	writeTestExercise(t, oldDir, "01_basics", "hello", "")
+	writeTestExercise(t, oldDir, "01_basics", "gone", "")
+	old := loadTestManager(t, oldDir)
+	for _, name := range []string{"hello", "gone"} {
+		if err := old.RecordRun(name, true, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := old.MarkExerciseCompleted(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exported, err := old.ExportProgress()
+	if err != nil {
+		t.Fatalf("ExportProgress failed: %v", err)
+	}
+
+	// hello was renamed to greeting, and gone was removed
+	newDir := t.TempDir()
+	testutil.WriteExercise(t, newDir, testutil.Exercise{Category: "01_basics", Name: "greeting", Fields: "aliases = [\"hello\"]\n"})
+	writeTestExercise(t, newDir, "01_basics", "variables", "")
+
+	em := loadTestManager(t, newDir)
+	if err := em.MarkExerciseCompleted("variables"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := em.ImportProgress(exported, nil, false)
+	if err != nil {
+		t.Fatalf("ImportProgress failed: %v", err)
+	}
+	if result.Renamed["hello"] != "greeting" || strings.Join(result.Removed, ",") != "gone" {
+		t.Errorf("Unexpected mapping: %+v", result)
+	}
+	reloaded := loadTestManager(t, newDir)
+	completed := reloaded.GetCompletedExercises()
+	if !completed["greeting"] || !completed["variables"] || completed["hello"] || completed["gone"] {
+		t.Errorf("Expected greeting and variables to be completed, got %v", completed)
+	}
+	if h, _ := reloaded.History("greeting"); h.Runs != 1 {
+		t.Errorf("Expected hello's history to move to greeting, got %+v", h)
+	}
+
+	// Importing again counts nothing twice; replacing discards the local progress
+	today := time.Now().Format(ActivityDateLayout)
+	activity := em.Activity()[today]
+	if _, err := em.ImportProgress(exported, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := em.History("greeting"); h.Runs != 1 {
+		t.Errorf("Expected a repeated import to keep 1 run, got %d", h.Runs)
+	}
+	if again := em.Activity()[today]; again != activity {
+		t.Errorf("Expected a repeated import to keep today's activity at %+v, got %+v", activity, again)
+	}
+	if _, err := em.ImportProgress(exported, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := em.History("greeting"); h.Runs != 1 || em.GetCompletedExercises()["variables"] {
+		t.Errorf("Expected replace to keep only the imported progress, got %+v and %v", h, em.GetCompletedExercises())
+	}
+}
@@ -0,0 +1,289 @@
+package exercise
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// maxProgressSize bounds the progress a progress server accepts or a client reads
+const maxProgressSize = 16 << 20
+
+// httpUpdateAttempts is how often HTTPStore.Update tries to save when other clients
+// keep saving between its load and its save, waiting a little longer each time
+const httpUpdateAttempts = 10
+
+// HTTPStore keeps progress on a goforgo progress server, such as one run with
+// 'goforgo progress serve' for a classroom. The progress is a TOML document at the
+// store's URL: GET reads it, and PUT replaces it only if its ETag still matches, so
+// concurrent saves are retried and merged instead of lost.
+type HTTPStore struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPStore returns a store keeping progress at url, sending token, if any, as a
+// bearer token
+func NewHTTPStore(url, token string, client *http.Client) *HTTPStore {
+	return &HTTPStore{url: url, token: token, client: client}
+}
+
+// do sends a request to the progress server
+func (s *HTTPStore) do(method string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/toml")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach progress server: %w", err)
+	}
+	return resp, nil
+}
+
+// Load fetches the progress
+func (s *HTTPStore) Load() (StoredProgress, error) {
+	resp, err := s.do(http.MethodGet, nil, nil)
+	if err != nil {
+		return StoredProgress{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// No progress saved yet
+		return StoredProgress{}, nil
+	default:
+		return StoredProgress{}, responseError(resp)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxProgressSize))
+	if err != nil {
+		return StoredProgress{}, fmt.Errorf("failed to read progress from server: %w", err)
+	}
+	stored := StoredProgress{Revision: resp.Header.Get("ETag")}
+	loaded, err := parseProgress(data)
+	if loaded == nil {
+		stored.Err = fmt.Errorf("progress at %s is corrupt (%v); goforgo won't overwrite it", s.url, err)
+		return stored, nil
+	}
+	stored.Progress, stored.Err = loaded, err
+	return stored, nil
+}
+
+// Update loads the progress, calls fn and saves the result if nobody else saved in
+// between, retrying otherwise
+func (s *HTTPStore) Update(fn func(current StoredProgress) (*Progress, error)) (string, error) {
+	for attempt := range httpUpdateAttempts {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * (10*time.Millisecond + rand.N(20*time.Millisecond)))
+		}
+		current, err := s.Load()
+		if err != nil {
+			return "", err
+		}
+		progress, err := fn(current)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(progress); err != nil {
+			return "", fmt.Errorf("failed to encode progress: %w", err)
+		}
+		header := http.Header{"Content-Type": {"application/toml"}}
+		if current.Revision == "" {
+			header.Set("If-None-Match", "*")
+		} else {
+			header.Set("If-Match", current.Revision)
+		}
+
+		resp, err := s.do(http.MethodPut, buf.Bytes(), header)
+		if err != nil {
+			return "", err
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusPreconditionFailed:
+			// Someone else saved first; merge with theirs and try again
+			continue
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return resp.Header.Get("ETag"), nil
+		default:
+			return "", responseError(resp)
+		}
+	}
+	return "", fmt.Errorf("progress at %s keeps changing; try again", s.url)
+}
+
+// Revision asks for the progress' ETag without fetching it
+func (s *HTTPStore) Revision() (string, error) {
+	resp, err := s.do(http.MethodHead, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Header.Get("ETag"), nil
+	case http.StatusNotFound:
+		return "", nil
+	}
+	return "", responseError(resp)
+}
+
+// Path returns "", since the progress isn't local
+func (s *HTTPStore) Path() string {
+	return ""
+}
+
+func (s *HTTPStore) String() string {
+	return s.url
+}
+
+// responseError describes an unexpected response from a progress server
+func responseError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if text := strings.TrimSpace(string(message)); text != "" {
+		return fmt.Errorf("progress server: %s: %s", resp.Status, text)
+	}
+	return fmt.Errorf("progress server: %s", resp.Status)
+}
+
+// learnerPattern is what learner names on a progress server look like
+var learnerPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// errRevisionChanged rejects a save made against an older revision
+var errRevisionChanged = errors.New("progress changed since it was loaded")
+
+// LearnerToken returns the bearer token a learner sends to a progress server run with
+// secret. It's derived from the secret and the learner's name, so it only opens that
+// learner's progress.
+func LearnerToken(secret, learner string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(learner))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewProgressHandler returns the HTTP handler of a progress server, keeping each
+// learner's progress at /progress/<learner> in the store open returns for them.
+// Unless secret is empty, requests must carry the learner's LearnerToken as a bearer token.
+func NewProgressHandler(open func(learner string) (ProgressStore, error), secret string) http.Handler {
+	mux := http.NewServeMux()
+	store := func(w http.ResponseWriter, r *http.Request) ProgressStore {
+		learner := r.PathValue("learner")
+		if !learnerPattern.MatchString(learner) {
+			http.Error(w, "invalid learner name", http.StatusBadRequest)
+			return nil
+		}
+		if secret != "" {
+			token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(LearnerToken(secret, learner))) != 1 {
+				http.Error(w, "missing or wrong token", http.StatusUnauthorized)
+				return nil
+			}
+		}
+		store, err := open(learner)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil
+		}
+		return store
+	}
+
+	mux.HandleFunc("GET /progress/{learner}", func(w http.ResponseWriter, r *http.Request) {
+		s := store(w, r)
+		if s == nil {
+			return
+		}
+		stored, err := s.Load()
+		switch {
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		case stored.Revision == "":
+			http.NotFound(w, r)
+			return
+		case stored.Progress == nil:
+			http.Error(w, stored.Err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(stored.Progress); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/toml")
+		w.Header().Set("ETag", etag(stored.Revision))
+		_, _ = w.Write(buf.Bytes())
+	})
+
+	mux.HandleFunc("PUT /progress/{learner}", func(w http.ResponseWriter, r *http.Request) {
+		s := store(w, r)
+		if s == nil {
+			return
+		}
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxProgressSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		progress, err := parseProgress(data)
+		if err != nil {
+			// Progress from a newer goforgo couldn't be stored without losing what's new in it
+			http.Error(w, fmt.Sprintf("invalid progress: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+		rev, err := s.Update(func(current StoredProgress) (*Progress, error) {
+			if current.Err != nil {
+				return nil, current.Err
+			}
+			if ifNoneMatch == "*" && current.Revision != "" || ifMatch != "" && ifMatch != etag(current.Revision) {
+				return nil, errRevisionChanged
+			}
+			return progress, nil
+		})
+		switch {
+		case errors.Is(err, errRevisionChanged):
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			w.Header().Set("ETag", etag(rev))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return mux
+}
+
+// etag quotes a revision for the ETag header
+func etag(revision string) string {
+	return `"` + revision + `"`
+}
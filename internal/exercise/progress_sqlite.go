@@ -0,0 +1,343 @@
+package exercise
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Registers the sqlite driver; pure Go, so it works in builds without cgo
+)
+
+// sqliteSchema creates the tables of a SQLite progress store. History and activity
+// get a row per exercise and day, so they can be queried without loading everything.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS progress (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS completed (
+	exercise TEXT PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS history (
+	exercise         TEXT PRIMARY KEY,
+	first_seen       TEXT NOT NULL DEFAULT '',
+	last_run         TEXT NOT NULL DEFAULT '',
+	completed_at     TEXT NOT NULL DEFAULT '',
+	runs             INTEGER NOT NULL DEFAULT 0,
+	runs_to_complete INTEGER NOT NULL DEFAULT 0,
+	failures         INTEGER NOT NULL DEFAULT 0,
+	hints_viewed     INTEGER NOT NULL DEFAULT 0,
+	time_spent       INTEGER NOT NULL DEFAULT 0,
+	failure_kinds    TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS activity (
+	day         TEXT PRIMARY KEY,
+	runs        INTEGER NOT NULL DEFAULT 0,
+	failures    INTEGER NOT NULL DEFAULT 0,
+	completions INTEGER NOT NULL DEFAULT 0,
+	time_spent  INTEGER NOT NULL DEFAULT 0
+);`
+
+// Keys of the progress table
+const (
+	sqliteRevision        = "revision" // Incremented by every save
+	sqliteVersion         = "version"
+	sqliteCurrentExercise = "current_exercise"
+	sqliteLastCompleted   = "last_completed"
+	sqliteActiveTrack     = "active_track"
+	sqliteLastUpdated     = "last_updated"
+)
+
+// SQLiteStore keeps progress in a SQLite database. Saves run in an immediate
+// transaction, which SQLite serialises between processes, and only write the history
+// and activity rows that changed.
+type SQLiteStore struct {
+	path string
+}
+
+// NewSQLiteStore returns a store keeping progress in the SQLite database at path,
+// which is created on first use
+func NewSQLiteStore(path string) *SQLiteStore {
+	return &SQLiteStore{path: path}
+}
+
+// open opens the database, creating the tables if needed. Transactions begin
+// immediately, taking the write lock, and wait for other processes holding it.
+func (s *SQLiteStore) open() (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+s.path+"?_txlock=immediate&_pragma=busy_timeout(10000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open progress database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open progress database %s: %w", s.path, err)
+	}
+	return db, nil
+}
+
+// Load reads the progress from the database
+func (s *SQLiteStore) Load() (StoredProgress, error) {
+	db, err := s.open()
+	if err != nil {
+		return StoredProgress{}, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return StoredProgress{}, fmt.Errorf("failed to read progress database: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	return s.load(tx)
+}
+
+// load reads the progress within a transaction
+func (s *SQLiteStore) load(tx *sql.Tx) (StoredProgress, error) {
+	values, err := sqliteValues(tx)
+	if err != nil {
+		return StoredProgress{}, err
+	}
+	stored := StoredProgress{Revision: values[sqliteRevision]}
+	if stored.Revision == "" {
+		// Nothing saved yet
+		return stored, nil
+	}
+
+	p := newProgress()
+	p.Version, _ = strconv.Atoi(values[sqliteVersion])
+	p.CurrentExercise = values[sqliteCurrentExercise]
+	p.LastCompleted = values[sqliteLastCompleted]
+	p.ActiveTrack = values[sqliteActiveTrack]
+	p.LastUpdated = parseSQLiteTime(values[sqliteLastUpdated])
+
+	rows, err := tx.Query(`SELECT exercise FROM completed`)
+	if err != nil {
+		return stored, fmt.Errorf("failed to read progress database: %w", err)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return stored, fmt.Errorf("failed to read progress database: %w", err)
+		}
+		p.CompletedExercises[name] = true
+	}
+	if err := rows.Close(); err != nil {
+		return stored, err
+	}
+
+	rows, err = tx.Query(`SELECT exercise, first_seen, last_run, completed_at, runs, runs_to_complete, failures, hints_viewed, time_spent, failure_kinds FROM history`)
+	if err != nil {
+		return stored, fmt.Errorf("failed to read progress database: %w", err)
+	}
+	for rows.Next() {
+		var name, firstSeen, lastRun, completedAt, kinds string
+		h := &ExerciseHistory{}
+		if err := rows.Scan(&name, &firstSeen, &lastRun, &completedAt, &h.Runs, &h.RunsToComplete, &h.Failures, &h.HintsViewed, &h.TimeSpent, &kinds); err != nil {
+			_ = rows.Close()
+			return stored, fmt.Errorf("failed to read progress database: %w", err)
+		}
+		h.FirstSeen, h.LastRun, h.CompletedAt = parseSQLiteTime(firstSeen), parseSQLiteTime(lastRun), parseSQLiteTime(completedAt)
+		if kinds != "" {
+			h.FailureKinds = strings.Split(kinds, ",")
+		}
+		p.History[name] = h
+	}
+	if err := rows.Close(); err != nil {
+		return stored, err
+	}
+
+	rows, err = tx.Query(`SELECT day, runs, failures, completions, time_spent FROM activity`)
+	if err != nil {
+		return stored, fmt.Errorf("failed to read progress database: %w", err)
+	}
+	for rows.Next() {
+		var day string
+		a := &DailyActivity{}
+		if err := rows.Scan(&day, &a.Runs, &a.Failures, &a.Completions, &a.TimeSpent); err != nil {
+			_ = rows.Close()
+			return stored, fmt.Errorf("failed to read progress database: %w", err)
+		}
+		p.Activity[day] = a
+	}
+	if err := rows.Close(); err != nil {
+		return stored, err
+	}
+
+	stored.Progress = p
+	if err := migrateProgress(p); err != nil {
+		stored.Err = fmt.Errorf("progress database %s: %w", s.path, err)
+	}
+	return stored, nil
+}
+
+// Update saves the progress fn returns in one transaction
+func (s *SQLiteStore) Update(fn func(current StoredProgress) (*Progress, error)) (string, error) {
+	db, err := s.open()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to lock progress database: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := s.load(tx)
+	if err != nil {
+		return "", err
+	}
+	progress, err := fn(current)
+	if err != nil {
+		return "", err
+	}
+	old := current.Progress
+	if old == nil {
+		old = newProgress()
+	}
+
+	revision, _ := strconv.Atoi(current.Revision)
+	rev := strconv.Itoa(revision + 1)
+	if err := s.save(tx, old, progress, rev); err != nil {
+		return "", fmt.Errorf("failed to write progress database: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to write progress database: %w", err)
+	}
+	return rev, nil
+}
+
+// save writes the differences between old, the stored progress, and p
+func (s *SQLiteStore) save(tx *sql.Tx, old, p *Progress, rev string) error {
+	values := map[string]string{
+		sqliteRevision:        rev,
+		sqliteVersion:         strconv.Itoa(p.Version),
+		sqliteCurrentExercise: p.CurrentExercise,
+		sqliteLastCompleted:   p.LastCompleted,
+		sqliteActiveTrack:     p.ActiveTrack,
+		sqliteLastUpdated:     formatSQLiteTime(p.LastUpdated),
+	}
+	for key, value := range values {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO progress (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return err
+		}
+	}
+
+	for name := range mergedKeys(old.CompletedExercises, p.CompletedExercises) {
+		switch done := p.CompletedExercises[name]; {
+		case done == old.CompletedExercises[name]:
+		case done:
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO completed (exercise) VALUES (?)`, name); err != nil {
+				return err
+			}
+		default:
+			if _, err := tx.Exec(`DELETE FROM completed WHERE exercise = ?`, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, h := range p.History {
+		if o := old.History[name]; o != nil && equalHistory(*o, *h) {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO history (exercise, first_seen, last_run, completed_at, runs, runs_to_complete, failures, hints_viewed, time_spent, failure_kinds) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			name, formatSQLiteTime(h.FirstSeen), formatSQLiteTime(h.LastRun), formatSQLiteTime(h.CompletedAt),
+			h.Runs, h.RunsToComplete, h.Failures, h.HintsViewed, int64(h.TimeSpent), strings.Join(h.FailureKinds, ",")); err != nil {
+			return err
+		}
+	}
+	for name := range old.History {
+		if p.History[name] == nil {
+			if _, err := tx.Exec(`DELETE FROM history WHERE exercise = ?`, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for day, a := range p.Activity {
+		if o := old.Activity[day]; o != nil && *o == *a {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO activity (day, runs, failures, completions, time_spent) VALUES (?, ?, ?, ?, ?)`,
+			day, a.Runs, a.Failures, a.Completions, int64(a.TimeSpent)); err != nil {
+			return err
+		}
+	}
+	for day := range old.Activity {
+		if p.Activity[day] == nil {
+			if _, err := tx.Exec(`DELETE FROM activity WHERE day = ?`, day); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Revision reads the revision counter
+func (s *SQLiteStore) Revision() (string, error) {
+	db, err := s.open()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	var rev string
+	err = db.QueryRow(`SELECT value FROM progress WHERE key = ?`, sqliteRevision).Scan(&rev)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return rev, err
+}
+
+// Path returns the database file
+func (s *SQLiteStore) Path() string {
+	return s.path
+}
+
+func (s *SQLiteStore) String() string {
+	return "sqlite:" + s.path
+}
+
+// sqliteValues reads the progress table
+func sqliteValues(tx *sql.Tx) (map[string]string, error) {
+	rows, err := tx.Query(`SELECT key, value FROM progress`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress database: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to read progress database: %w", err)
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+func equalHistory(a, b ExerciseHistory) bool {
+	return a.FirstSeen.Equal(b.FirstSeen) && a.LastRun.Equal(b.LastRun) && a.CompletedAt.Equal(b.CompletedAt) &&
+		a.Runs == b.Runs && a.RunsToComplete == b.RunsToComplete && a.Failures == b.Failures && a.HintsViewed == b.HintsViewed &&
+		a.TimeSpent == b.TimeSpent && slices.Equal(a.FailureKinds, b.FailureKinds)
+}
+
+// formatSQLiteTime stores a time as RFC 3339 text, "" for the zero time
+func formatSQLiteTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseSQLiteTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, s)
+	return t
+}
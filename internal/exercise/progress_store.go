@@ -0,0 +1,143 @@
+package exercise
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProgressStoreEnv selects where progress is kept, machine-wide:
+//
+//	toml (or unset)       .goforgo-progress.toml in the workspace
+//	sqlite                .goforgo-progress.db in the workspace
+//	sqlite:<path>         a SQLite database at path
+//	http(s)://host/path   a goforgo progress server, see NewProgressHandler
+//
+// GOFORGO_PROGRESS_TOKEN is sent to progress servers as a bearer token. A server run
+// with GOFORGO_PROGRESS_SECRET gives each learner a token of their own, see LearnerToken.
+const (
+	ProgressStoreEnv  = "GOFORGO_PROGRESS_STORE"
+	ProgressTokenEnv  = "GOFORGO_PROGRESS_TOKEN"
+	ProgressSecretEnv = "GOFORGO_PROGRESS_SECRET"
+)
+
+// ProgressStore keeps a learner's progress. Several goforgo processes may use the same
+// store at once; Update is what keeps their saves from overwriting each other.
+type ProgressStore interface {
+	// Load returns the stored progress. An error means the store couldn't be reached.
+	Load() (StoredProgress, error)
+
+	// Update calls fn with the stored progress and stores the progress fn returns,
+	// unless fn fails. No other Update of the same store happens in between. It
+	// returns the revision of the stored progress.
+	Update(fn func(current StoredProgress) (*Progress, error)) (string, error)
+
+	// Revision returns the revision of the stored progress without loading it
+	Revision() (string, error)
+
+	// Path returns the local file holding the progress, to watch for changes, or ""
+	// when the store isn't local
+	Path() string
+
+	// String describes the store for messages
+	String() string
+}
+
+// StoredProgress is progress as kept by a ProgressStore
+type StoredProgress struct {
+	Progress *Progress // Nil when nothing is stored yet, or the stored progress can't be read
+	Revision string    // Changes whenever the stored progress does, "" when nothing is stored
+	Err      error     // Why the stored progress must not be overwritten: it's corrupt or from a newer goforgo
+}
+
+// OpenProgressStore returns the progress store spec selects for the workspace at
+// basePath, in the format of ProgressStoreEnv
+func OpenProgressStore(basePath, spec string) (ProgressStore, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch {
+	case spec == "" || spec == "toml":
+		return NewTOMLStore(filepath.Join(basePath, ".goforgo-progress.toml")), nil
+	case kind == "sqlite":
+		path := filepath.Join(basePath, ".goforgo-progress.db")
+		if arg != "" {
+			path = arg
+		}
+		return NewSQLiteStore(path), nil
+	case kind == "http" || kind == "https":
+		return NewHTTPStore(spec, os.Getenv(ProgressTokenEnv), &http.Client{Timeout: 10 * time.Second}), nil
+	}
+	return nil, fmt.Errorf("unknown progress store %q in %s; use toml, sqlite, sqlite:<path> or an http(s) URL", spec, ProgressStoreEnv)
+}
+
+// errStoreNotEmpty stops importTOMLProgress from replacing progress already in a store
+var errStoreNotEmpty = errors.New("progress store is not empty")
+
+// importTOMLProgress copies the workspace's progress file into store while the store is
+// still empty, so switching GOFORGO_PROGRESS_STORE keeps the learner's progress. The
+// file is left in place.
+func importTOMLProgress(basePath string, store ProgressStore) error {
+	file := NewTOMLStore(filepath.Join(basePath, ".goforgo-progress.toml"))
+	if _, err := os.Stat(file.path); err != nil {
+		return nil
+	}
+	if rev, err := store.Revision(); err != nil || rev != "" {
+		return err
+	}
+
+	local, err := file.Load()
+	if err == nil {
+		err = local.Err
+	}
+	if err != nil {
+		return fmt.Errorf("can't copy %s into %s: %w", file.path, store, err)
+	}
+	if local.Progress == nil {
+		return nil
+	}
+
+	_, err = store.Update(func(current StoredProgress) (*Progress, error) {
+		if current.Revision != "" {
+			// Another goforgo process got there first
+			return nil, errStoreNotEmpty
+		}
+		return local.Progress, nil
+	})
+	if err != nil && !errors.Is(err, errStoreNotEmpty) {
+		return fmt.Errorf("can't copy %s into %s: %w", file.path, store, err)
+	}
+	return nil
+}
+
+// SetProgressStore switches the manager to another progress store and loads the
+// progress from it. Problems with that progress are added to Warnings.
+func (em *ExerciseManager) SetProgressStore(store ProgressStore) {
+	em.store = store
+	em.ProgressPath = store.Path()
+	em.warn(em.loadProgress())
+	em.UpdateExerciseProgress()
+	em.warn(em.applyActiveTrack())
+}
+
+// ProgressStore returns the store progress is kept in
+func (em *ExerciseManager) ProgressStore() ProgressStore {
+	return em.store
+}
+
+// unavailableStore stands in for a progress store that couldn't be opened, so the
+// manager still works with in-memory progress but never saves it
+type unavailableStore struct {
+	err error
+}
+
+func (s unavailableStore) Load() (StoredProgress, error) { return StoredProgress{}, s.err }
+func (s unavailableStore) Revision() (string, error)     { return "", s.err }
+func (s unavailableStore) Path() string                  { return "" }
+func (s unavailableStore) String() string                { return "unavailable progress store" }
+
+func (s unavailableStore) Update(func(StoredProgress) (*Progress, error)) (string, error) {
+	return "", s.err
+}
@@ -0,0 +1,268 @@
+package exercise
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOpenProgressStore(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", "/work/.goforgo-progress.toml"},
+		{"toml", "/work/.goforgo-progress.toml"},
+		{"sqlite", "sqlite:/work/.goforgo-progress.db"},
+		{"sqlite:/data/alice.db", "sqlite:/data/alice.db"},
+		{"https://class.example.com/progress/alice", "https://class.example.com/progress/alice"},
+	}
+	for _, tt := range tests {
+		store, err := OpenProgressStore("/work", tt.spec)
+		if err != nil {
+			t.Errorf("OpenProgressStore(%q) failed: %v", tt.spec, err)
+			continue
+		}
+		if store.String() != tt.want {
+			t.Errorf("OpenProgressStore(%q) = %s, want %s", tt.spec, store, tt.want)
+		}
+	}
+
+	if _, err := OpenProgressStore("/work", "redis://localhost"); err == nil {
+		t.Error("Expected an unknown store to fail")
+	}
+}
+
+// progressStores returns a fresh store of every kind, keeping their data in dir
+func progressStores(t *testing.T, dir string) map[string]ProgressStore {
+	t.Helper()
+	server := httptest.NewServer(NewProgressHandler(func(learner string) (ProgressStore, error) {
+		return NewTOMLStore(filepath.Join(dir, learner+".toml")), nil
+	}, "secret"))
+	t.Cleanup(server.Close)
+
+	return map[string]ProgressStore{
+		"toml":   NewTOMLStore(filepath.Join(dir, "progress.toml")),
+		"sqlite": NewSQLiteStore(filepath.Join(dir, "progress.db")),
+		"http":   NewHTTPStore(server.URL+"/progress/alice", LearnerToken("secret", "alice"), server.Client()),
+	}
+}
+
+func TestProgressStores(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+
+	for kind, store := range progressStores(t, t.TempDir()) {
+		t.Run(kind, func(t *testing.T) {
+			if rev, err := store.Revision(); err != nil || rev != "" {
+				t.Fatalf("Expected an empty store to have no revision, got %q, %v", rev, err)
+			}
+
+			em := loadTestManager(t, tempDir)
+			em.SetProgressStore(store)
+			if err := em.RecordRun("hello", false, FailureBuild); err != nil {
+				t.Fatalf("RecordRun failed: %v", err)
+			}
+			if err := em.RecordRun("hello", true, ""); err != nil {
+				t.Fatalf("RecordRun failed: %v", err)
+			}
+			if err := em.AddTimeSpent("hello", 90*time.Second); err != nil {
+				t.Fatalf("AddTimeSpent failed: %v", err)
+			}
+			if err := em.MarkExerciseCompleted("hello"); err != nil {
+				t.Fatalf("MarkExerciseCompleted failed: %v", err)
+			}
+			rev, err := store.Revision()
+			if err != nil || rev == "" {
+				t.Fatalf("Expected a revision after saving, got %q, %v", rev, err)
+			}
+
+			// Another process sees everything that was saved
+			other := loadTestManager(t, tempDir)
+			other.SetProgressStore(store)
+			want, _ := em.History("hello")
+			got, ok := other.History("hello")
+			if !ok || got.Runs != 2 || got.RunsToComplete != 2 || got.Failures != 1 || got.TimeSpent != 90*time.Second ||
+				strings.Join(got.FailureKinds, ",") != FailureBuild || !got.FirstSeen.Equal(want.FirstSeen) || !got.CompletedAt.Equal(want.CompletedAt) {
+				t.Errorf("Expected the history to round trip, got %+v, want %+v", got, want)
+			}
+			if !other.GetCompletedExercises()["hello"] || other.progress.LastCompleted != "hello" {
+				t.Errorf("Expected hello to be completed last, got %+v", other.progress)
+			}
+			if a := other.Activity()[time.Now().Format(ActivityDateLayout)]; a.Runs != 2 || a.Completions != 1 {
+				t.Errorf("Expected today's activity to round trip, got %+v", a)
+			}
+
+			// Both save without having seen each other's changes; nothing is lost
+			if err := em.RecordRun("variables", true, ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := other.RecordRun("hello", true, ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := other.MarkExerciseCompleted("variables"); err != nil {
+				t.Fatal(err)
+			}
+			if changed, _ := em.ReloadProgress(); !changed {
+				t.Fatal("Expected the other process' save to be noticed")
+			}
+			if changed, _ := em.ReloadProgress(); changed {
+				t.Error("Expected no reload when nothing changed")
+			}
+			if h, _ := em.History("hello"); h.Runs != 3 {
+				t.Errorf("Expected 3 runs of hello, got %d", h.Runs)
+			}
+			if h, _ := em.History("variables"); h.Runs != 1 || !em.GetCompletedExercises()["variables"] {
+				t.Errorf("Expected variables to be run once and completed, got %+v", h)
+			}
+
+			// Many writers at once: every run is counted
+			const writers, runs = 4, 5
+			var wg sync.WaitGroup
+			for range writers {
+				writer := loadTestManager(t, tempDir)
+				writer.SetProgressStore(store)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range runs {
+						if err := writer.RecordRun("variables", true, ""); err != nil {
+							t.Errorf("RecordRun failed: %v", err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			em.ReloadProgress()
+			if h, _ := em.History("variables"); h.Runs != 1+writers*runs {
+				t.Errorf("Expected %d runs of variables, got %d", 1+writers*runs, h.Runs)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_NewerVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	store := NewSQLiteStore(filepath.Join(tempDir, "progress.db"))
+
+	_, err := store.Update(func(StoredProgress) (*Progress, error) {
+		p := newProgress()
+		p.Version = ProgressVersion + 1
+		p.CompletedExercises["hello"] = true
+		return p, nil
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	em := loadTestManager(t, tempDir)
+	em.SetProgressStore(store)
+	if !em.GetCompletedExercises()["hello"] {
+		t.Error("Expected progress from a newer goforgo to stay readable")
+	}
+	if err := em.RecordRun("hello", true, ""); err == nil || !strings.Contains(err.Error(), "upgrade goforgo") {
+		t.Errorf("Expected saving over a newer schema to fail, got %v", err)
+	}
+}
+
+func TestNewExerciseManager_ImportsProgressFile(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+
+	t.Setenv(ProgressStoreEnv, "")
+	em := loadTestManager(t, tempDir)
+	if err := em.MarkExerciseCompleted("hello"); err != nil {
+		t.Fatalf("MarkExerciseCompleted failed: %v", err)
+	}
+
+	// The first open of an empty store copies the progress file into it
+	t.Setenv(ProgressStoreEnv, "sqlite")
+	em = loadTestManager(t, tempDir)
+	if len(em.Warnings()) != 0 || !em.GetCompletedExercises()["hello"] {
+		t.Fatalf("Expected the progress file to be imported, got %v (warnings %v)", em.GetCompletedExercises(), em.Warnings())
+	}
+	if err := em.MarkExerciseCompleted("variables"); err != nil {
+		t.Fatalf("MarkExerciseCompleted failed: %v", err)
+	}
+
+	// Later opens keep what the store has
+	em = loadTestManager(t, tempDir)
+	if !em.GetCompletedExercises()["variables"] {
+		t.Error("Expected the store not to be imported into again")
+	}
+
+	// A progress file that can't be imported keeps the new store from starting over
+	otherDir := t.TempDir()
+	writeTestExercise(t, otherDir, "01_basics", "hello", "")
+	if err := os.WriteFile(filepath.Join(otherDir, ".goforgo-progress.toml"), []byte("[completed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	em = loadTestManager(t, otherDir)
+	if err := em.MarkExerciseCompleted("hello"); err == nil || !strings.Contains(err.Error(), "can't copy") {
+		t.Errorf("Expected saving to fail after a failed import, got %v", err)
+	}
+}
+
+func TestProgressHandler(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(NewProgressHandler(func(learner string) (ProgressStore, error) {
+		return NewTOMLStore(filepath.Join(dir, learner+".toml")), nil
+	}, "secret"))
+	defer server.Close()
+
+	request := func(method, path, token string, header map[string]string, body string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	alice := LearnerToken("secret", "alice")
+	progress := "version = 2\n[completed_exercises]\nhello = true\n"
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		header map[string]string
+		body   string
+		want   int
+	}{
+		{"no token", "GET", "/progress/alice", "", nil, "", http.StatusUnauthorized},
+		{"wrong token", "GET", "/progress/alice", "guess", nil, "", http.StatusUnauthorized},
+		{"server secret", "GET", "/progress/alice", "secret", nil, "", http.StatusUnauthorized},
+		{"another learner's token", "GET", "/progress/bob", alice, nil, "", http.StatusUnauthorized},
+		{"invalid learner", "GET", "/progress/..alice", alice, nil, "", http.StatusBadRequest},
+		{"nothing saved", "GET", "/progress/alice", alice, nil, "", http.StatusNotFound},
+		{"first save", "PUT", "/progress/alice", alice, map[string]string{"If-None-Match": "*"}, progress, http.StatusNoContent},
+		{"saved", "GET", "/progress/alice", alice, nil, "", http.StatusOK},
+		{"first save again", "PUT", "/progress/alice", alice, map[string]string{"If-None-Match": "*"}, progress, http.StatusPreconditionFailed},
+		{"stale save", "PUT", "/progress/alice", alice, map[string]string{"If-Match": `"stale"`}, progress, http.StatusPreconditionFailed},
+		{"invalid progress", "PUT", "/progress/alice", alice, nil, "[completed", http.StatusBadRequest},
+		{"newer progress", "PUT", "/progress/alice", alice, nil, "version = 99\n", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if resp := request(tt.method, tt.path, tt.token, tt.header, tt.body); resp.StatusCode != tt.want {
+			t.Errorf("%s: got %s, want %d", tt.name, resp.Status, tt.want)
+		}
+	}
+}
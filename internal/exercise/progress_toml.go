@@ -0,0 +1,178 @@
+package exercise
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLStore keeps progress in a TOML file, the default. Saves replace the file
+// atomically under an advisory lock, and a file that can't be decoded is backed up
+// and never overwritten.
+type TOMLStore struct {
+	path string
+}
+
+// NewTOMLStore returns a store keeping progress in the TOML file at path
+func NewTOMLStore(path string) *TOMLStore {
+	return &TOMLStore{path: path}
+}
+
+// Load reads the progress file
+func (s *TOMLStore) Load() (StoredProgress, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		// No progress file exists yet, start fresh
+		return StoredProgress{}, nil
+	}
+	if err != nil {
+		return StoredProgress{}, fmt.Errorf("failed to read progress file: %w", err)
+	}
+	return s.decode(data), nil
+}
+
+// decode parses the content of the progress file. Content that isn't a valid progress
+// file is backed up and returned without Progress; progress from a newer goforgo is
+// returned along with its error.
+func (s *TOMLStore) decode(data []byte) StoredProgress {
+	stored := StoredProgress{Revision: fileRevision(data)}
+	loaded, err := parseProgress(data)
+	if loaded != nil {
+		stored.Progress, stored.Err = loaded, err
+		return stored
+	}
+
+	backup, backupErr := s.backup(data)
+	if backupErr != nil {
+		stored.Err = fmt.Errorf("progress file %s is corrupt (%v) and couldn't be backed up (%v); goforgo won't overwrite it", s.path, err, backupErr)
+	} else {
+		stored.Err = fmt.Errorf("progress file %s is corrupt (%v); a copy was saved to %s. goforgo won't overwrite it until you fix or remove it", s.path, err, backup)
+	}
+	return stored
+}
+
+// backup copies unreadable progress next to the progress file, unless an identical
+// backup already exists, and returns the backup's path
+func (s *TOMLStore) backup(data []byte) (string, error) {
+	backups, _ := filepath.Glob(s.path + ".corrupt-*")
+	for _, backup := range backups {
+		if existing, err := os.ReadFile(backup); err == nil && bytes.Equal(existing, data) {
+			return backup, nil
+		}
+	}
+
+	backup := s.path + ".corrupt-" + time.Now().Format("20060102-150405")
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// Update rewrites the progress file atomically while holding the progress lock
+func (s *TOMLStore) Update(fn func(current StoredProgress) (*Progress, error)) (string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return "", fmt.Errorf("failed to lock progress file: %w", err)
+	}
+	defer unlock()
+
+	current, err := s.Load()
+	if err != nil {
+		return "", err
+	}
+	progress, err := fn(current)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(progress); err != nil {
+		return "", fmt.Errorf("failed to encode progress: %w", err)
+	}
+	if err := writeFileAtomic(s.path, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to write progress file: %w", err)
+	}
+	return fileRevision(buf.Bytes()), nil
+}
+
+// Revision hashes the progress file
+func (s *TOMLStore) Revision() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fileRevision(data), nil
+}
+
+// Path returns the progress file
+func (s *TOMLStore) Path() string {
+	return s.path
+}
+
+func (s *TOMLStore) String() string {
+	return s.path
+}
+
+// lock takes the advisory lock that serialises progress writes between goforgo
+// processes, blocking until it's free. The lock lives in its own file because the
+// progress file itself is replaced on every save.
+func (s *TOMLStore) lock() (unlock func(), err error) {
+	file, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}, nil
+}
+
+// fileRevision identifies the content of a progress file
+func fileRevision(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic replaces path with data so that readers, and a crash at any point,
+// see either the old content or the new: data goes to a synced temporary file in the
+// same directory, which is then renamed over path.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
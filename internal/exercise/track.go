@@ -0,0 +1,241 @@
+package exercise
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Track is a named subset of the course, such as an onboarding path for one team. Its
+// steps pick whole categories or single exercises, in the order they should be done.
+type Track struct {
+	Name        string      `toml:"name"` // Defaults to the file name without .toml
+	Title       string      `toml:"title"`
+	Description string      `toml:"description"`
+	Steps       []TrackStep `toml:"steps"`
+
+	Source string `toml:"-"` // Where the track was loaded from, for error messages
+}
+
+// TrackStep adds one category or one exercise to a track. Optional steps are listed and
+// recommended, but don't count towards the track's progress.
+type TrackStep struct {
+	Category string `toml:"category,omitempty"` // e.g. "11_concurrency"; every exercise in course order
+	Exercise string `toml:"exercise,omitempty"` // e.g. "42_kafka/producers.go", relative to the exercises directory
+	Optional bool   `toml:"optional,omitempty"`
+}
+
+// trackScope is a track resolved against the loaded exercises
+type trackScope struct {
+	track     *Track
+	exercises []*Exercise
+	optional  map[*Exercise]bool
+}
+
+// LoadTracks reads every track definition: the built-in tracks, then the *.toml files in
+// the workspace tracks directory, which replace built-in tracks of the same name.
+// Tracks are returned sorted by name.
+func (em *ExerciseManager) LoadTracks() ([]*Track, error) {
+	byName := make(map[string]*Track)
+	sources := []fs.FS{em.BuiltinTracks}
+	if _, err := os.Stat(em.TracksPath); err == nil {
+		sources = append(sources, os.DirFS(em.TracksPath))
+	}
+
+	for i, fsys := range sources {
+		if fsys == nil {
+			continue
+		}
+		files, err := fs.Glob(fsys, "*.toml")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			source := path.Join("tracks", file)
+			if i > 0 {
+				source = filepath.Join(em.TracksPath, file)
+			}
+			data, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read track %s: %w", source, err)
+			}
+			track := &Track{Source: source}
+			if _, err := toml.Decode(string(data), track); err != nil {
+				return nil, fmt.Errorf("failed to parse track %s: %w", source, err)
+			}
+			if track.Name == "" {
+				track.Name = strings.TrimSuffix(file, ".toml")
+			}
+			byName[track.Name] = track
+		}
+	}
+
+	tracks := make([]*Track, 0, len(byName))
+	for _, track := range byName {
+		tracks = append(tracks, track)
+	}
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].Name < tracks[j].Name })
+	return tracks, nil
+}
+
+// FindTrack returns the track with the given name
+func (em *ExerciseManager) FindTrack(name string) (*Track, error) {
+	tracks, err := em.LoadTracks()
+	if err != nil {
+		return nil, err
+	}
+	for _, track := range tracks {
+		if track.Name == name {
+			return track, nil
+		}
+	}
+	return nil, fmt.Errorf("track '%s' not found. Run 'goforgo track list' to see the available tracks", name)
+}
+
+// resolveTrack expands a track's steps into exercises. An exercise picked by several
+// steps keeps its first position, and is optional only if every step picking it is.
+// Every prerequisite of a required exercise must be a required exercise of the track.
+func (em *ExerciseManager) resolveTrack(track *Track) (*trackScope, error) {
+	scope := &trackScope{track: track, optional: make(map[*Exercise]bool)}
+	seen := make(map[*Exercise]bool)
+	add := func(ex *Exercise, optional bool) {
+		if !seen[ex] {
+			seen[ex] = true
+			scope.exercises = append(scope.exercises, ex)
+			scope.optional[ex] = optional
+		} else if !optional {
+			scope.optional[ex] = false
+		}
+	}
+
+	for i, step := range track.Steps {
+		switch {
+		case (step.Category == "") == (step.Exercise == ""):
+			return nil, fmt.Errorf("track %s: step %d must set exactly one of category or exercise", track.Source, i+1)
+		case step.Category != "":
+			found := false
+			for _, ex := range em.exercises {
+				if ex.Info.Category == step.Category {
+					add(ex, step.Optional)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("track %s: category %q has no exercises", track.Source, step.Category)
+			}
+		default:
+			key := em.relatedKey(filepath.Join(em.ExercisesPath, filepath.FromSlash(step.Exercise)))
+			var match *Exercise
+			for _, ex := range em.exercises {
+				if em.relatedKey(ex.MetadataPath) == key {
+					match = ex
+					break
+				}
+			}
+			if match == nil {
+				return nil, fmt.Errorf("track %s: exercise %q not found", track.Source, step.Exercise)
+			}
+			add(match, step.Optional)
+		}
+	}
+
+	// A required exercise can't wait on one the learner may skip or never sees
+	for _, ex := range scope.exercises {
+		if scope.optional[ex] {
+			continue
+		}
+		for _, req := range ex.Requires {
+			switch {
+			case !seen[req]:
+				return nil, fmt.Errorf("track %s: %s requires %s, which is not in the track",
+					track.Source, em.relatedKey(ex.MetadataPath), em.relatedKey(req.MetadataPath))
+			case scope.optional[req]:
+				return nil, fmt.Errorf("track %s: %s requires %s, which is optional in the track",
+					track.Source, em.relatedKey(ex.MetadataPath), em.relatedKey(req.MetadataPath))
+			}
+		}
+	}
+	return scope, nil
+}
+
+// UseTrack scopes the manager to a track and saves it as the active track. An empty
+// name clears the scope, going back to the whole course.
+func (em *ExerciseManager) UseTrack(name string) error {
+	if name == "" {
+		em.scope = nil
+		em.progress.ActiveTrack = ""
+		return em.saveProgress()
+	}
+
+	track, err := em.FindTrack(name)
+	if err != nil {
+		return err
+	}
+	scope, err := em.resolveTrack(track)
+	if err != nil {
+		return err
+	}
+	em.scope = scope
+	em.progress.ActiveTrack = track.Name
+	return em.saveProgress()
+}
+
+// applyActiveTrack restores the track saved in the progress file. A track that no longer
+// exists or no longer resolves is returned as an error and the whole course is used instead.
+func (em *ExerciseManager) applyActiveTrack() error {
+	em.scope = nil
+	if em.progress.ActiveTrack == "" {
+		return nil
+	}
+	track, err := em.FindTrack(em.progress.ActiveTrack)
+	if err == nil {
+		em.scope, err = em.resolveTrack(track)
+	}
+	if err != nil {
+		return fmt.Errorf("ignoring active track: %w", err)
+	}
+	return nil
+}
+
+// ActiveTrack returns the track the manager is scoped to, or nil for the whole course
+func (em *ExerciseManager) ActiveTrack() *Track {
+	if em.scope == nil {
+		return nil
+	}
+	return em.scope.track
+}
+
+// IsOptional reports whether ex is an optional exercise of the active track
+func (em *ExerciseManager) IsOptional(ex *Exercise) bool {
+	return em.scope != nil && em.scope.optional[ex]
+}
+
+// TrackProgress returns the completed and total number of required exercises in a track
+func (em *ExerciseManager) TrackProgress(track *Track) (completed int, total int, err error) {
+	scope, err := em.resolveTrack(track)
+	if err != nil {
+		return 0, 0, err
+	}
+	completed, total = scope.progress()
+	return completed, total, nil
+}
+
+// progress counts the completed and total required exercises of the scope
+func (s *trackScope) progress() (completed int, total int) {
+	for _, ex := range s.exercises {
+		if s.optional[ex] {
+			continue
+		}
+		total++
+		if ex.Completed {
+			completed++
+		}
+	}
+	return completed, total
+}
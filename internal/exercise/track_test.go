@@ -0,0 +1,154 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stonecharioteer/goforgo/internal/testutil"
+)
+
+func TestExerciseManager_Tracks(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	writeTestExercise(t, tempDir, "01_basics", "variables", "")
+	writeTestExercise(t, tempDir, "02_net", "tcp", "")
+	writeTestExercise(t, tempDir, "03_k8s", "client", "")
+
+	builtin := fstest.MapFS{
+		"sre.toml": {Data: []byte(`
+title = "SRE"
+
+[[steps]]
+category = "03_k8s"
+
+[[steps]]
+category = "02_net"
+
+[[steps]]
+exercise = "01_basics/hello.go"
+optional = true
+`)},
+		"broken.toml": {Data: []byte(`
+[[steps]]
+category = "99_missing"
+`)},
+	}
+	// A workspace track replaces the built-in one of the same name
+	if err := os.MkdirAll(filepath.Join(tempDir, "tracks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "tracks", "broken.toml"), []byte(`
+title = "Fixed"
+
+[[steps]]
+exercise = "01_basics/variables"
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	em := NewExerciseManager(tempDir)
+	em.BuiltinTracks = builtin
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	tracks, err := em.LoadTracks()
+	if err != nil {
+		t.Fatalf("LoadTracks failed: %v", err)
+	}
+	if len(tracks) != 2 || tracks[0].Name != "broken" || tracks[0].Title != "Fixed" || tracks[1].Name != "sre" {
+		t.Fatalf("Unexpected tracks: %+v", tracks)
+	}
+
+	if err := em.UseTrack("sre"); err != nil {
+		t.Fatalf("UseTrack failed: %v", err)
+	}
+	var names []string
+	for _, ex := range em.GetExercises() {
+		names = append(names, ex.Info.Name)
+	}
+	if len(names) != 3 || names[0] != "client" || names[1] != "tcp" || names[2] != "hello" {
+		t.Errorf("Expected track order client, tcp, hello; got %v", names)
+	}
+	if _, total, _ := em.GetProgressStats(); total != 2 {
+		t.Errorf("Expected optional exercises not to count towards progress, got total %d", total)
+	}
+	if next := em.GetNextExercise(); next.Info.Name != "client" {
+		t.Errorf("Expected client as the next exercise, got %s", next.Info.Name)
+	}
+
+	for _, name := range []string{"client", "tcp"} {
+		if err := em.MarkExerciseCompleted(name); err != nil {
+			t.Fatalf("Failed to mark %s completed: %v", name, err)
+		}
+	}
+	if next := em.GetNextExercise(); next == nil || next.Info.Name != "hello" {
+		t.Errorf("Expected the optional exercise once the required ones are done, got %v", next)
+	}
+
+	// The active track is saved and restored on the next load
+	em2 := NewExerciseManager(tempDir)
+	em2.BuiltinTracks = builtin
+	if err := em2.LoadExercises(); err != nil {
+		t.Fatalf("Failed to reload exercises: %v", err)
+	}
+	if em2.ActiveTrack() == nil || em2.ActiveTrack().Name != "sre" {
+		t.Fatalf("Expected the sre track to stay active, got %v", em2.ActiveTrack())
+	}
+	if completed, total, _ := em2.GetProgressStats(); completed != 2 || total != 2 {
+		t.Errorf("Expected 2/2 on the track, got %d/%d", completed, total)
+	}
+
+	if err := em2.UseTrack(""); err != nil {
+		t.Fatalf("Failed to clear the track: %v", err)
+	}
+	if len(em2.GetExercises()) != 4 {
+		t.Errorf("Expected the whole course after clearing the track, got %d exercises", len(em2.GetExercises()))
+	}
+	if err := em2.UseTrack("missing"); err == nil {
+		t.Error("Expected an error for an unknown track")
+	}
+}
+
+func TestExerciseManager_TrackPrerequisites(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTestExercise(t, tempDir, "01_basics", "hello", "")
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "02_net", Name: "tcp", Fields: "prerequisites = [\"01_basics/hello.go\"]\n"})
+
+	em := NewExerciseManager(tempDir)
+	em.BuiltinTracks = fstest.MapFS{
+		"outside.toml": {Data: []byte("[[steps]]\ncategory = \"02_net\"\n")},
+		"optional.toml": {Data: []byte(`
+[[steps]]
+category = "01_basics"
+optional = true
+
+[[steps]]
+category = "02_net"
+`)},
+		"complete.toml": {Data: []byte("[[steps]]\ncategory = \"01_basics\"\n\n[[steps]]\ncategory = \"02_net\"\n")},
+		"skippable.toml": {Data: []byte(`
+[[steps]]
+category = "02_net"
+optional = true
+`)},
+	}
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	for name, want := range map[string]string{"outside": "not in the track", "optional": "optional in the track"} {
+		if err := em.UseTrack(name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Track %s: expected an error containing %q, got %v", name, want, err)
+		}
+	}
+	// Optional exercises may depend on anything
+	for _, name := range []string{"complete", "skippable"} {
+		if err := em.UseTrack(name); err != nil {
+			t.Errorf("Track %s: unexpected error: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package profile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/textdiff"
+)
+
+// hunk is one change of a unified diff: the lines it expects and the lines replacing them
+type hunk struct {
+	oldStart int // 1-based line the old lines started at when the diff was made
+	old      []string
+	new      []string
+}
+
+// makePatch returns a unified diff turning pristine into modified, labelled with name,
+// or "" when they're equal
+func makePatch(name, pristine, modified string) string {
+	diff := textdiff.Unified(strings.Split(pristine, "\n"), strings.Split(modified, "\n"), nil)
+	if len(diff) < 2 {
+		return ""
+	}
+	diff[0].Text, diff[1].Text = "--- a/"+name, "+++ b/"+name
+	return textdiff.Format(diff) + "\n"
+}
+
+// parsePatch reads the hunks of a unified diff made by makePatch
+func parsePatch(patch string) ([]hunk, error) {
+	var hunks []hunk
+	for i, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@ "):
+			fields := strings.Fields(line)
+			if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") {
+				return nil, fmt.Errorf("line %d: malformed hunk header %q", i+1, line)
+			}
+			start, _, _ := strings.Cut(strings.TrimPrefix(fields[1], "-"), ",")
+			oldStart, err := strconv.Atoi(start)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: malformed hunk header %q", i+1, line)
+			}
+			hunks = append(hunks, hunk{oldStart: oldStart})
+		case len(hunks) == 0:
+			// File headers before the first hunk
+		case line == "":
+			return nil, fmt.Errorf("line %d: empty line inside a hunk", i+1)
+		default:
+			h := &hunks[len(hunks)-1]
+			text := line[1:]
+			switch line[0] {
+			case ' ':
+				h.old = append(h.old, text)
+				h.new = append(h.new, text)
+			case '-':
+				h.old = append(h.old, text)
+			case '+':
+				h.new = append(h.new, text)
+			default:
+				return nil, fmt.Errorf("line %d: unexpected %q inside a hunk", i+1, line)
+			}
+		}
+	}
+	return hunks, nil
+}
+
+// applyPatch applies hunks to original. Each hunk is looked for where the diff says it
+// was, then progressively further away, so a patch made against an older version of
+// an exercise still applies when lines were added or removed elsewhere in the file.
+func applyPatch(original string, hunks []hunk) (string, error) {
+	lines := strings.Split(original, "\n")
+	offset, floor := 0, 0
+	for i, h := range hunks {
+		hint := max(h.oldStart-1, 0) + offset
+		pos := findLines(lines, h.old, hint, floor)
+		if pos < 0 {
+			return "", fmt.Errorf("hunk %d (line %d) doesn't match the current exercise", i+1, h.oldStart)
+		}
+
+		replaced := make([]string, 0, len(lines)-len(h.old)+len(h.new))
+		replaced = append(replaced, lines[:pos]...)
+		replaced = append(replaced, h.new...)
+		replaced = append(replaced, lines[pos+len(h.old):]...)
+		lines = replaced
+
+		offset += len(h.new) - len(h.old)
+		floor = pos + len(h.new)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// findLines returns the index at or after floor where want occurs in lines, preferring
+// the one closest to hint, or -1
+func findLines(lines, want []string, hint, floor int) int {
+	last := len(lines) - len(want)
+	if len(want) == 0 {
+		return min(max(hint, floor), len(lines))
+	}
+	for distance := 0; hint-distance >= floor || hint+distance <= last; distance++ {
+		for _, pos := range []int{hint - distance, hint + distance} {
+			if pos >= floor && pos <= last && equalAt(lines, want, pos) {
+				return pos
+			}
+		}
+	}
+	return -1
+}
+
+func equalAt(lines, want []string, pos int) bool {
+	for i, line := range want {
+		if lines[pos+i] != line {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,288 @@
+// Package profile moves a learner between machines. It exports the progress file,
+// history included, and optionally the learner's changes to exercise files into a
+// single zip archive, and imports such an archive into another workspace.
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// FormatVersion is the archive layout version this goforgo writes
+const FormatVersion = 1
+
+// Entries of a profile archive
+const (
+	manifestName = "manifest.toml"
+	progressName = "progress.toml"
+	sourcesDir   = "sources"
+)
+
+// Manifest describes an exported profile
+type Manifest struct {
+	Format         int               `toml:"format"` // FormatVersion of the goforgo that wrote it
+	GoforgoVersion string            `toml:"goforgo_version"`
+	ExportedAt     time.Time         `toml:"exported_at"`
+	Completed      int               `toml:"completed"`
+	Exercises      map[string]string `toml:"exercises"` // Exercise names to their paths, e.g. "01_basics/hello"
+	Sources        []Source          `toml:"sources,omitempty"`
+}
+
+// Source is one exercise file the learner changed, stored as a unified diff against
+// the pristine file embedded in goforgo
+type Source struct {
+	Exercise string `toml:"exercise"`         // Exercise name when exported
+	Key      string `toml:"key"`              // Exercise path when exported, e.g. "01_basics/hello"
+	File     string `toml:"file"`             // Relative to the exercises directory
+	Within   string `toml:"within"`           // Relative to the directory of the exercise's TOML
+	Single   bool   `toml:"single,omitempty"` // The only file of its exercise
+	Diff     string `toml:"diff"`             // Entry holding the diff in the archive
+}
+
+// ExportOptions control what goes into a profile archive
+type ExportOptions struct {
+	Version string // goforgo version recorded in the manifest
+	Sources bool   // Include changed exercise files as diffs
+}
+
+// ImportOptions control how a profile archive is applied
+type ImportOptions struct {
+	Replace     bool // Replace local progress instead of adding the archive's to it
+	Force       bool // Overwrite exercise files that were also changed locally
+	SkipSources bool // Import progress only
+}
+
+// ImportReport is what Import did
+type ImportReport struct {
+	Manifest *Manifest
+	Progress *exercise.ProgressImport
+	Applied  []string        // Exercise files restored from the archive
+	Skipped  []SkippedSource // Exercise files left alone
+}
+
+// SkippedSource is an exercise file from the archive that wasn't restored, and why
+type SkippedSource struct {
+	File   string
+	Reason string
+}
+
+// Export writes the learner's profile to w as a zip archive. pristine holds the
+// exercises as shipped, rooted at the exercises directory, to diff changed files against.
+func Export(w io.Writer, em *exercise.ExerciseManager, pristine fs.FS, opts ExportOptions) (*Manifest, error) {
+	progress, err := em.ExportProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Format:         FormatVersion,
+		GoforgoVersion: opts.Version,
+		ExportedAt:     time.Now(),
+		Exercises:      make(map[string]string),
+	}
+	for _, done := range em.GetCompletedExercises() {
+		if done {
+			manifest.Completed++
+		}
+	}
+	for _, ex := range em.AllExercises() {
+		manifest.Exercises[ex.Info.Name] = em.ExerciseKey(ex)
+	}
+
+	patches := make(map[string]string)
+	if opts.Sources {
+		for _, ex := range em.AllExercises() {
+			for _, file := range ex.Files {
+				source, patch, err := exportSource(em, pristine, ex, file)
+				if err != nil {
+					return nil, err
+				}
+				if patch == "" || patches[source.Diff] != "" {
+					continue
+				}
+				manifest.Sources = append(manifest.Sources, source)
+				patches[source.Diff] = patch
+			}
+		}
+	}
+
+	var encoded bytes.Buffer
+	if err := toml.NewEncoder(&encoded).Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	archive := zip.NewWriter(w)
+	if err := writeEntry(archive, manifestName, encoded.Bytes(), manifest.ExportedAt); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, progressName, progress, manifest.ExportedAt); err != nil {
+		return nil, err
+	}
+	for _, source := range manifest.Sources {
+		if err := writeEntry(archive, source.Diff, []byte(patches[source.Diff]), manifest.ExportedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// exportSource diffs one exercise file against its pristine version. The patch is
+// empty when the file is unchanged or missing.
+func exportSource(em *exercise.ExerciseManager, pristine fs.FS, ex *exercise.Exercise, file string) (Source, string, error) {
+	rel, err := filepath.Rel(em.ExercisesPath, file)
+	if err != nil {
+		return Source{}, "", err
+	}
+	within, err := filepath.Rel(filepath.Dir(ex.MetadataPath), file)
+	if err != nil {
+		return Source{}, "", err
+	}
+	source := Source{
+		Exercise: ex.Info.Name,
+		Key:      em.ExerciseKey(ex),
+		File:     filepath.ToSlash(rel),
+		Within:   filepath.ToSlash(within),
+		Single:   len(ex.Files) == 1,
+		Diff:     path.Join(sourcesDir, filepath.ToSlash(rel)+".diff"),
+	}
+
+	current, err := os.ReadFile(file)
+	if err != nil {
+		return source, "", nil
+	}
+	original, _ := fs.ReadFile(pristine, source.File) // Files the learner added diff against nothing
+	return source, makePatch(source.File, string(original), string(current)), nil
+}
+
+// Import applies a profile archive to the workspace of em: the progress is merged into
+// the local progress, and changed exercise files are restored by patching the pristine
+// exercises. Exercises renamed since the export are found through their aliases.
+func Import(r io.ReaderAt, size int64, em *exercise.ExerciseManager, pristine fs.FS, opts ImportOptions) (*ImportReport, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not a goforgo profile archive: %w", err)
+	}
+
+	manifestData, err := readEntry(archive, manifestName)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if _, err := toml.Decode(string(manifestData), manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestName, err)
+	}
+	if manifest.Format > FormatVersion {
+		return nil, fmt.Errorf("profile archive format %d is newer than this goforgo supports (%d); upgrade goforgo", manifest.Format, FormatVersion)
+	}
+
+	progress, err := readEntry(archive, progressName)
+	if err != nil {
+		return nil, err
+	}
+	report := &ImportReport{Manifest: manifest}
+	if report.Progress, err = em.ImportProgress(progress, manifest.Exercises, opts.Replace); err != nil {
+		return nil, err
+	}
+
+	if opts.SkipSources {
+		return report, nil
+	}
+	for _, source := range manifest.Sources {
+		patch, err := readEntry(archive, source.Diff)
+		if err != nil {
+			return nil, err
+		}
+		target, err := importSource(em, pristine, source, string(patch), opts.Force)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedSource{File: source.File, Reason: err.Error()})
+			continue
+		}
+		report.Applied = append(report.Applied, target)
+	}
+	return report, nil
+}
+
+// importSource restores one changed exercise file and returns where it was written
+func importSource(em *exercise.ExerciseManager, pristine fs.FS, source Source, patch string, force bool) (string, error) {
+	ex := em.ResolveExercise(source.Key)
+	if ex == nil {
+		ex = em.ResolveExercise(source.Exercise)
+	}
+	if ex == nil {
+		return "", fmt.Errorf("exercise '%s' no longer exists", source.Exercise)
+	}
+
+	target := filepath.Join(filepath.Dir(ex.MetadataPath), filepath.FromSlash(source.Within))
+	if source.Single && len(ex.Files) == 1 {
+		target = ex.Files[0]
+	}
+	if !slices.Contains(ex.Files, target) {
+		return "", fmt.Errorf("no longer part of exercise '%s'", ex.Info.Name)
+	}
+
+	rel, err := filepath.Rel(em.ExercisesPath, target)
+	if err != nil {
+		return "", err
+	}
+	original, _ := fs.ReadFile(pristine, filepath.ToSlash(rel))
+	hunks, err := parsePatch(patch)
+	if err != nil {
+		return "", fmt.Errorf("invalid diff: %w", err)
+	}
+	patched, err := applyPatch(string(original), hunks)
+	if err != nil {
+		rejected := target + ".rej"
+		if writeErr := os.WriteFile(rejected, []byte(patch), 0644); writeErr != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%v; the diff was saved to %s", err, rejected)
+	}
+
+	current, err := os.ReadFile(target)
+	switch {
+	case err == nil && string(current) == patched:
+		return target, nil
+	case err == nil && string(current) != string(original) && !force:
+		return "", fmt.Errorf("changed locally; use --force to overwrite")
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+	return target, os.WriteFile(target, []byte(patched), 0644)
+}
+
+// writeEntry adds a file to the archive
+func writeEntry(archive *zip.Writer, name string, data []byte, modified time.Time) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// readEntry reads a file of the archive
+func readEntry(archive *zip.Reader, name string) ([]byte, error) {
+	f, err := archive.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("profile archive has no %s: %w", name, err)
+	}
+	defer func() { _ = f.Close() }()
+	return io.ReadAll(f)
+}
@@ -0,0 +1,152 @@
+package profile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/testutil"
+)
+
+const pristineHello = `package main
+
+import "fmt"
+
+// TODO: print the greeting
+func main() {
+	fmt.Println("Hello, World!")
+}
+`
+
+// loadManager loads the exercises under root in a fresh manager
+func loadManager(t *testing.T, root string) *exercise.ExerciseManager {
+	t.Helper()
+	em := exercise.NewExerciseManager(root)
+	em.Quiet = true
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+	return em
+}
+
+func TestPatch(t *testing.T) {
+	modified := strings.Replace(pristineHello, "Hello, World!", "Hello, GoForGo!", 1)
+	patch := makePatch("01_basics/hello.go", pristineHello, modified)
+	if !strings.HasPrefix(patch, "--- a/01_basics/hello.go\n+++ b/01_basics/hello.go\n@@ ") {
+		t.Errorf("Unexpected patch headers:\n%s", patch)
+	}
+	if makePatch("x.go", pristineHello, pristineHello) != "" {
+		t.Error("Expected no patch for an unchanged file")
+	}
+
+	hunks, err := parsePatch(patch)
+	if err != nil {
+		t.Fatalf("parsePatch failed: %v", err)
+	}
+	if got, err := applyPatch(pristineHello, hunks); err != nil || got != modified {
+		t.Errorf("applyPatch = %q, %v; want the modified file", got, err)
+	}
+
+	// A newer pristine file with lines added above the change still takes the patch
+	newer := strings.Replace(pristineHello, "import \"fmt\"\n", "import \"fmt\"\n\n// Added in a later goforgo\n// to explain things\n", 1)
+	want := strings.Replace(newer, "Hello, World!", "Hello, GoForGo!", 1)
+	if got, err := applyPatch(newer, hunks); err != nil || got != want {
+		t.Errorf("applyPatch on a newer file = %q, %v; want %q", got, err, want)
+	}
+
+	// One whose changed lines differ doesn't
+	if _, err := applyPatch(strings.Replace(pristineHello, "Hello, World!", "Hi!", 1), hunks); err == nil {
+		t.Error("Expected a conflicting file to fail")
+	}
+
+	// New files diff against nothing
+	added := makePatch("01_basics/extra.go", "", "package main\n")
+	hunks, _ = parsePatch(added)
+	if got, err := applyPatch("", hunks); err != nil || got != "package main\n" {
+		t.Errorf("applyPatch of an added file = %q, %v", got, err)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	pristine := fstest.MapFS{
+		"01_basics/hello.go": {Data: []byte(pristineHello)},
+		"01_basics/gone.go":  {Data: []byte("package main\n")},
+	}
+
+	// The learner solves hello on one machine
+	oldDir := t.TempDir()
+	testutil.WriteExercise(t, oldDir, testutil.Exercise{Category: "01_basics", Name: "hello", Source: pristineHello})
+	testutil.WriteExercise(t, oldDir, testutil.Exercise{Category: "01_basics", Name: "gone", Source: "package main\n"})
+	solved := strings.Replace(pristineHello, "Hello, World!", "Hello, GoForGo!", 1)
+	if err := os.WriteFile(filepath.Join(oldDir, "exercises", "01_basics", "hello.go"), []byte(solved), 0644); err != nil {
+		t.Fatal(err)
+	}
+	em := loadManager(t, oldDir)
+	for _, name := range []string{"hello", "gone"} {
+		if err := em.RecordRun(name, true, ""); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+		if err := em.MarkExerciseCompleted(name); err != nil {
+			t.Fatalf("MarkExerciseCompleted failed: %v", err)
+		}
+	}
+
+	var archive bytes.Buffer
+	manifest, err := Export(&archive, em, pristine, ExportOptions{Version: "1.0.0", Sources: true})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if manifest.Completed != 2 || len(manifest.Sources) != 1 || manifest.Sources[0].File != "01_basics/hello.go" {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+
+	// A newer goforgo renamed hello to greeting and dropped gone
+	newDir := t.TempDir()
+	testutil.WriteExercise(t, newDir, testutil.Exercise{Category: "01_basics", Name: "greeting", Source: pristineHello, Fields: "aliases = [\"01_basics/hello.go\"]\n"})
+	newPristine := fstest.MapFS{"01_basics/greeting.go": {Data: []byte(pristineHello)}}
+	em = loadManager(t, newDir)
+
+	data := archive.Bytes()
+	report, err := Import(bytes.NewReader(data), int64(len(data)), em, newPristine, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.Progress.Renamed["hello"] != "greeting" || strings.Join(report.Progress.Removed, ",") != "gone" {
+		t.Errorf("Unexpected progress mapping: %+v", report.Progress)
+	}
+	if ex, _ := em.GetExerciseByName("greeting"); !ex.Completed {
+		t.Error("Expected greeting to be completed")
+	}
+	if h, _ := loadManager(t, newDir).History("greeting"); h.Runs != 1 {
+		t.Errorf("Expected the imported history to be saved, got %+v", h)
+	}
+	greeting := filepath.Join(newDir, "exercises", "01_basics", "greeting.go")
+	if got, _ := os.ReadFile(greeting); string(got) != solved {
+		t.Errorf("Expected the solution to be restored into greeting.go, got:\n%s", got)
+	}
+	if len(report.Applied) != 1 || len(report.Skipped) != 0 {
+		t.Errorf("Unexpected sources report: %+v", report)
+	}
+
+	// Local changes aren't overwritten without Force
+	if err := os.WriteFile(greeting, []byte("package main // mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = Import(bytes.NewReader(data), int64(len(data)), em, newPristine, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(report.Skipped) != 1 || !strings.Contains(report.Skipped[0].Reason, "--force") {
+		t.Errorf("Expected the locally changed file to be skipped, got %+v", report.Skipped)
+	}
+	if _, err := Import(bytes.NewReader(data), int64(len(data)), em, newPristine, ImportOptions{Force: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if got, _ := os.ReadFile(greeting); string(got) != solved {
+		t.Error("Expected Force to overwrite the local change")
+	}
+}
@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+	"github.com/stonecharioteer/goforgo/internal/deps"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// analyzerBuildDir holds analyzers built from a module, under privateBuildRoot. Each
+// module gets its own directory, so a binary is built once and reused.
+const analyzerBuildDir = "analyzers"
+
+// analyzerBinary returns the path of an external analyzer, building it first when it
+// is declared by module. Versioned modules (pkg@v1.2.3) are installed on their own;
+// unversioned packages are built with the exercise module's dependencies.
+func (r *Runner) analyzerBinary(exerciseDir string, a exercise.AnalyzerSpec) (string, error) {
+	if a.Path != "" {
+		if _, err := os.Stat(a.Path); err != nil {
+			return "", fmt.Errorf("analyzer binary not found at %s", a.Path)
+		}
+		return a.Path, nil
+	}
+
+	pkg, _, versioned := strings.Cut(a.Module, "@")
+	dir := filepath.Join(r.workingDir, privateBuildRoot, analyzerBuildDir,
+		strings.NewReplacer("/", "_", "@", "_", ":", "_").Replace(a.Module))
+	bin := filepath.Join(dir, path.Base(pkg))
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	if _, err := os.Stat(bin); err == nil {
+		return bin, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create analyzer directory: %w", err)
+	}
+
+	var success bool
+	var output string
+	var err error
+	if versioned {
+		success, output, err = r.runGoCommandWithInput(exerciseDir, commandInput{Env: map[string]string{"GOBIN": dir}}, "install", a.Module)
+	} else {
+		success, output, err = r.runGoCommand(exerciseDir, "build", "-o", bin, pkg)
+	}
+	if err == nil && !success {
+		err = fmt.Errorf("%s", output)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to build analyzer %s: %w", a.Module, err)
+	}
+	return bin, nil
+}
+
+// runAnalyzer runs one external analyzer over the exercise and turns its diagnostics
+// into a static check result. Any diagnostic fails the check.
+func (r *Runner) runAnalyzer(exerciseDir string, ex *exercise.Exercise, a exercise.AnalyzerSpec) (StaticCheckResult, error) {
+	name := a.DisplayName()
+	result := StaticCheckResult{Name: name}
+
+	bin, err := r.analyzerBinary(exerciseDir, a)
+	if err != nil {
+		return result, err
+	}
+	targets := buildTargets(ex)
+	if len(targets) == 0 {
+		targets = testTargets(ex)
+	}
+
+	var output string
+	if a.Protocol == "json" {
+		output, err = r.runAnalyzerJSON(exerciseDir, bin, append(append([]string{"-json"}, a.Flags...), targets...))
+	} else {
+		args := append(append([]string{"-vettool=" + bin, "-json"}, a.Flags...), targets...)
+		_, output, err = r.runGoCommand(exerciseDir, "vet", args...)
+	}
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", name, err)
+	}
+
+	diags, err := analysis.ParseDiagnostics([]byte(output))
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", name, err)
+	}
+	findings := make([]analysis.Finding, len(diags))
+	for i, d := range diags {
+		findings[i] = d.Finding()
+	}
+	result.Success, result.Output = analysis.Report(name+" reported no issues.", findings)
+	return result, nil
+}
+
+// runAnalyzerJSON runs a standalone analysis driver, which prints its JSON diagnostics
+// to stdout. Exit status is ignored: drivers exit non-zero when they find something.
+func (r *Runner) runAnalyzerJSON(dir, bin string, args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), deps.GoEnv(r.workingDir)...)
+	stdout := newCappedOutputBuffer(maxCommandOutputBytes)
+	stderr := newCappedOutputBuffer(maxCommandOutputBytes)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("analyzer timed out after %v", r.timeout)
+	}
+	if strings.TrimSpace(stdout.String()) == "" && err != nil {
+		return "", fmt.Errorf("analyzer failed: %v\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,282 @@
+package runner
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// Defaults for bench mode when the exercise TOML leaves them out
+const (
+	defaultBenchCount = 5
+	defaultBenchTime  = "100ms"
+)
+
+// benchLine matches a benchmark result line, e.g.
+// BenchmarkConcat-8   	  500000	      2380 ns/op	     512 B/op	       3 allocs/op
+var benchLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+(\d+)\s+([\d.]+) ns/op(.*)$`)
+
+// BenchStat summarizes every sample of one benchmark across -count runs
+type BenchStat struct {
+	Name        string    `json:"name"`
+	Samples     []float64 `json:"samples"` // ns/op of each run
+	NsPerOp     float64   `json:"ns_per_op"`
+	Variance    float64   `json:"variance"` // Largest deviation from the median, as a fraction of it
+	BytesPerOp  float64   `json:"bytes_per_op"`
+	AllocsPerOp float64   `json:"allocs_per_op"`
+	HasMem      bool      `json:"has_mem"`
+}
+
+// parseBenchOutput collects benchmark samples from go test -bench output, in first-seen order
+func parseBenchOutput(output string) []*BenchStat {
+	var stats []*BenchStat
+	byName := make(map[string]*BenchStat)
+	allocs := make(map[string][]float64)
+	bytes := make(map[string][]float64)
+
+	for _, line := range strings.Split(output, "\n") {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+
+		name := m[1]
+		ns, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			continue
+		}
+
+		stat := byName[name]
+		if stat == nil {
+			stat = &BenchStat{Name: name}
+			byName[name] = stat
+			stats = append(stats, stat)
+		}
+		stat.Samples = append(stat.Samples, ns)
+
+		if b, ok := benchMetric(m[4], "B/op"); ok {
+			bytes[name] = append(bytes[name], b)
+			stat.HasMem = true
+		}
+		if a, ok := benchMetric(m[4], "allocs/op"); ok {
+			allocs[name] = append(allocs[name], a)
+			stat.HasMem = true
+		}
+	}
+
+	for _, stat := range stats {
+		stat.NsPerOp = median(stat.Samples)
+		stat.Variance = maxDeviation(stat.Samples, stat.NsPerOp)
+		stat.BytesPerOp = median(bytes[stat.Name])
+		stat.AllocsPerOp = median(allocs[stat.Name])
+	}
+	return stats
+}
+
+// benchMetric extracts the value preceding unit from the tail of a benchmark line
+func benchMetric(rest, unit string) (float64, bool) {
+	fields := strings.Fields(rest)
+	for i := 1; i < len(fields); i++ {
+		if fields[i] == unit {
+			v, err := strconv.ParseFloat(fields[i-1], 64)
+			return v, err == nil
+		}
+	}
+	return 0, false
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func maxDeviation(values []float64, center float64) float64 {
+	if center == 0 {
+		return 0
+	}
+	var dev float64
+	for _, v := range values {
+		dev = math.Max(dev, math.Abs(v-center)/center)
+	}
+	return dev
+}
+
+// formatNs renders a ns/op value with a readable unit
+func formatNs(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.2fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fµs", ns/1e3)
+	default:
+		return fmt.Sprintf("%.2fns", ns)
+	}
+}
+
+// formatBenchStats renders a benchstat-style table: median time/op ± the largest deviation
+func formatBenchStats(stats []*BenchStat) string {
+	width := len("name")
+	for _, stat := range stats {
+		width = max(width, len(stat.Name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s  %20s  %12s  %12s\n", width, "name", "time/op", "B/op", "allocs/op")
+	for _, stat := range stats {
+		timeCol := fmt.Sprintf("%s ± %.0f%%", formatNs(stat.NsPerOp), stat.Variance*100)
+		bytesCol, allocsCol := "-", "-"
+		if stat.HasMem {
+			bytesCol = strconv.FormatFloat(stat.BytesPerOp, 'f', -1, 64)
+			allocsCol = strconv.FormatFloat(stat.AllocsPerOp, 'f', -1, 64)
+		}
+		fmt.Fprintf(&b, "%-*s  %20s  %12s  %12s  (n=%d)\n", width, stat.Name, timeCol, bytesCol, allocsCol, len(stat.Samples))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// checkBenchThresholds compares benchmark results against the exercise's thresholds
+// and returns one line per check, plus whether all of them passed.
+func checkBenchThresholds(stats []*BenchStat, thresholds []exercise.BenchThreshold) (bool, []string) {
+	byName := make(map[string]*BenchStat, len(stats))
+	for _, stat := range stats {
+		byName[stat.Name] = stat
+	}
+
+	passed := true
+	var lines []string
+	report := func(ok bool, format string, args ...interface{}) {
+		icon := "✅"
+		if !ok {
+			icon = "❌"
+			passed = false
+		}
+		lines = append(lines, icon+" "+fmt.Sprintf(format, args...))
+	}
+
+	for _, t := range thresholds {
+		stat := byName[t.Benchmark]
+		if stat == nil {
+			report(false, "%s: benchmark did not run", t.Benchmark)
+			continue
+		}
+
+		if t.MaxNsPerOp > 0 {
+			report(stat.NsPerOp <= t.MaxNsPerOp, "%s: %s/op (limit %s/op)",
+				t.Benchmark, formatNs(stat.NsPerOp), formatNs(t.MaxNsPerOp))
+		}
+		if t.MaxAllocsPerOp != nil {
+			if !stat.HasMem {
+				report(false, "%s: allocations not reported (run with -benchmem)", t.Benchmark)
+			} else {
+				report(stat.AllocsPerOp <= float64(*t.MaxAllocsPerOp), "%s: %g allocs/op (limit %d)",
+					t.Benchmark, stat.AllocsPerOp, *t.MaxAllocsPerOp)
+			}
+		}
+		if t.FasterThan != "" {
+			baseline := byName[t.FasterThan]
+			if baseline == nil {
+				report(false, "%s: baseline %s did not run", t.Benchmark, t.FasterThan)
+				continue
+			}
+			speedup := baseline.NsPerOp / math.Max(stat.NsPerOp, 1e-9)
+			report(speedup >= t.RequiredSpeedup(), "%s: %.1f× faster than %s (need %.1f×)",
+				t.Benchmark, speedup, t.FasterThan, t.RequiredSpeedup())
+		}
+	}
+
+	return passed, lines
+}
+
+// benchArgs returns the go test flags for bench mode. Tests still run, so a
+// fast but wrong implementation doesn't pass.
+func benchArgs(cfg exercise.BenchConfig, prefix string) []string {
+	count := cfg.Count
+	if count <= 0 {
+		count = defaultBenchCount
+	}
+	benchtime := cfg.Benchtime
+	if benchtime == "" {
+		benchtime = defaultBenchTime
+	}
+	pattern := cfg.Pattern
+	if pattern == "" {
+		pattern = "."
+	}
+
+	return []string{
+		prefix + "bench=" + pattern,
+		prefix + "benchmem",
+		prefix + "count=" + strconv.Itoa(count),
+		prefix + "benchtime=" + benchtime,
+	}
+}
+
+// runBenchmarks runs bench mode and fills in the result
+func (r *Runner) runBenchmarks(exerciseDir string, ex *exercise.Exercise, result *Result) {
+	var success bool
+	var output string
+	var err error
+
+	sb, sbErr := r.prepareSandbox(exerciseDir, ex, result, append([]string{"test", "-c"}, testTargets(ex)...)...)
+	switch {
+	case sbErr != nil:
+		err = fmt.Errorf("sandbox setup failed: %w", sbErr)
+	case sb == nil:
+		args := append(benchArgs(ex.Validation.Bench, "-"), testTargets(ex)...)
+		success, output, err = r.runGoCommand(exerciseDir, "test", args...)
+	default:
+		var killReason string
+		success, output, killReason, err = sb.run(benchArgs(ex.Validation.Bench, "-test."), commandInput{})
+		if killReason != "" {
+			result.Validation.KilledReason = killReason
+			output = strings.TrimSpace(output + "\n\n" + killedMessage(sb.mode, killReason))
+		}
+		r.finishSandbox(sb, result)
+	}
+
+	result.Validation.BenchOutput = output
+	if err != nil {
+		result.Error = fmt.Sprintf("Benchmark command failed: %v", err)
+		result.Output = output
+		return
+	}
+	if !success {
+		result.Output = output
+		return
+	}
+
+	stats := parseBenchOutput(output)
+	result.Validation.Benchmarks = stats
+	if len(stats) == 0 {
+		result.Output = "❌ No benchmarks ran. Check the benchmark names and pattern.\n\n" + output
+		return
+	}
+
+	passed, checks := checkBenchThresholds(stats, ex.Validation.Bench.Thresholds)
+	result.Validation.BenchSuccess = passed
+	result.Success = passed
+
+	var b strings.Builder
+	b.WriteString("⏱️  Benchmark results:\n")
+	b.WriteString(formatBenchStats(stats))
+	if len(checks) > 0 {
+		b.WriteString("\n\n🎯 Thresholds:\n  ")
+		b.WriteString(strings.Join(checks, "\n  "))
+	}
+	result.Output = b.String()
+}
@@ -0,0 +1,310 @@
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// coverProfileName is the -coverprofile file written inside the sandbox copy
+const coverProfileName = "coverage.out"
+
+// coverBlock is one line of a -coverprofile file, e.g.
+// /path/to/file.go:15.2,16.50 2 1
+type coverBlock struct {
+	File      string
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NumStmt   int
+	Count     int
+}
+
+// FuncCoverage is the statement coverage of one function, as go tool cover -func reports it
+type FuncCoverage struct {
+	Name    string `json:"name"` // Function name, or Type.Method for methods
+	File    string `json:"file"` // Base name of the source file
+	Line    int    `json:"line"`
+	Covered int    `json:"covered"` // Statements executed at least once
+	Total   int    `json:"total"`
+}
+
+// Percent returns the share of the function's statements the tests executed
+func (f FuncCoverage) Percent() float64 {
+	if f.Total == 0 {
+		return 100
+	}
+	return 100 * float64(f.Covered) / float64(f.Total)
+}
+
+// parseCoverProfile reads the blocks of a cover profile. Blocks repeated by
+// several test binaries are merged, keeping the highest count.
+func parseCoverProfile(data string) ([]coverBlock, error) {
+	var blocks []coverBlock
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		colon := strings.LastIndex(line, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+		fields := strings.Fields(line[colon+1:])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+
+		b := coverBlock{File: line[:colon]}
+		_, err := fmt.Sscanf(fields[0], "%d.%d,%d.%d", &b.StartLine, &b.StartCol, &b.EndLine, &b.EndCol)
+		if err != nil {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+		if b.NumStmt, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+		if b.Count, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+
+		key := b.File + ":" + fields[0]
+		if i, ok := index[key]; ok {
+			blocks[i].Count = max(blocks[i].Count, b.Count)
+			continue
+		}
+		index[key] = len(blocks)
+		blocks = append(blocks, b)
+	}
+	return blocks, scanner.Err()
+}
+
+// totalCoverage returns the percentage of statements executed, like go test's "coverage:" line
+func totalCoverage(blocks []coverBlock) float64 {
+	var covered, total int
+	for _, b := range blocks {
+		total += b.NumStmt
+		if b.Count > 0 {
+			covered += b.NumStmt
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+// funcCoverage attributes profile blocks to the functions that contain them.
+// resolve maps a profile file name to a path on disk.
+func funcCoverage(blocks []coverBlock, resolve func(string) string) ([]FuncCoverage, error) {
+	byFile := make(map[string][]coverBlock)
+	var files []string
+	for _, b := range blocks {
+		if _, ok := byFile[b.File]; !ok {
+			files = append(files, b.File)
+		}
+		byFile[b.File] = append(byFile[b.File], b)
+	}
+	sort.Strings(files)
+
+	var funcs []FuncCoverage
+	for _, name := range files {
+		path := resolve(name)
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s for coverage: %w", filepath.Base(path), err)
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			start, end := fset.Position(fn.Pos()), fset.Position(fn.End())
+			fc := FuncCoverage{Name: funcName(fn), File: filepath.Base(path), Line: start.Line}
+
+			for _, b := range byFile[name] {
+				if !blockWithin(b, start, end) {
+					continue
+				}
+				fc.Total += b.NumStmt
+				if b.Count > 0 {
+					fc.Covered += b.NumStmt
+				}
+			}
+			funcs = append(funcs, fc)
+		}
+	}
+	return funcs, nil
+}
+
+// blockWithin reports whether a profile block lies inside [start, end]
+func blockWithin(b coverBlock, start, end token.Position) bool {
+	if b.StartLine < start.Line || (b.StartLine == start.Line && b.StartCol < start.Column) {
+		return false
+	}
+	if b.EndLine > end.Line || (b.EndLine == end.Line && b.EndCol > end.Column) {
+		return false
+	}
+	return true
+}
+
+// funcName returns fn's name, qualified with its receiver type for methods
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ = t.X
+	case *ast.IndexListExpr:
+		typ = t.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// checkCoverage compares coverage against the exercise's min_coverage and must_cover
+// and returns one line per check, plus whether all of them passed.
+func checkCoverage(total float64, funcs []FuncCoverage, v exercise.ExerciseValidation) (bool, []string) {
+	passed := true
+	var lines []string
+	report := func(ok bool, format string, args ...interface{}) {
+		icon := "✅"
+		if !ok {
+			icon = "❌"
+			passed = false
+		}
+		lines = append(lines, icon+" "+fmt.Sprintf(format, args...))
+	}
+
+	if v.MinCoverage > 0 {
+		report(total >= v.MinCoverage, "%.1f%% of statements covered (need %.1f%%)", total, v.MinCoverage)
+	}
+
+	byName := make(map[string]FuncCoverage, len(funcs))
+	for _, fc := range funcs {
+		byName[fc.Name] = fc
+	}
+	for _, name := range v.MustCover {
+		fc, ok := byName[name]
+		switch {
+		case !ok:
+			report(false, "%s: function not found", name)
+		case fc.Covered == 0:
+			report(false, "%s: never executed by the tests", name)
+		default:
+			report(true, "%s: executed (%.1f%%)", name, fc.Percent())
+		}
+	}
+
+	return passed, lines
+}
+
+// formatFuncCoverage renders per-function coverage like go tool cover -func
+func formatFuncCoverage(funcs []FuncCoverage, total float64) string {
+	width := len("total")
+	for _, fc := range funcs {
+		width = max(width, len(fc.Name))
+	}
+
+	var b strings.Builder
+	for _, fc := range funcs {
+		fmt.Fprintf(&b, "%-*s  %6.1f%%  (%s:%d)\n", width, fc.Name, fc.Percent(), fc.File, fc.Line)
+	}
+	fmt.Fprintf(&b, "%-*s  %6.1f%%", width, "total", total)
+	return b.String()
+}
+
+// collectCoverage reads a cover profile into result.Validation
+func (r *Runner) collectCoverage(exerciseDir, profile string, result *Result) error {
+	data, err := os.ReadFile(profile)
+	if err != nil {
+		return fmt.Errorf("tests did not write a coverage profile: %w", err)
+	}
+	blocks, err := parseCoverProfile(string(data))
+	if err != nil {
+		return err
+	}
+
+	funcs, err := funcCoverage(blocks, r.coverageResolver(exerciseDir))
+	if err != nil {
+		return err
+	}
+	result.Validation.Coverage = totalCoverage(blocks)
+	result.Validation.FuncCoverage = funcs
+	return nil
+}
+
+// coverageResolver maps profile file names to paths on disk. Files passed to go test
+// by name are reported with absolute paths; package exercises use import paths
+// within the enclosing module.
+func (r *Runner) coverageResolver(exerciseDir string) func(string) string {
+	root := r.findModuleRoot(exerciseDir)
+	module := modulePath(root)
+
+	return func(name string) string {
+		if filepath.IsAbs(name) || root == "" {
+			return name
+		}
+		if rel, ok := strings.CutPrefix(name, module+"/"); ok {
+			return filepath.Join(root, filepath.FromSlash(rel))
+		}
+		return filepath.Join(exerciseDir, filepath.Base(name))
+	}
+}
+
+// modulePath returns the module path declared in root/go.mod, or "" if there is none
+func modulePath(root string) string {
+	if root == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// applyCoverage checks a passing test run against the coverage targets and appends the report
+func applyCoverage(ex *exercise.Exercise, result *Result) {
+	v := result.Validation
+	passed, checks := checkCoverage(v.Coverage, v.FuncCoverage, ex.Validation)
+	result.Validation.CoverageSuccess = passed
+	result.Success = passed
+
+	var b strings.Builder
+	b.WriteString(result.Output)
+	b.WriteString("\n\n📊 Coverage:\n")
+	b.WriteString(formatFuncCoverage(v.FuncCoverage, v.Coverage))
+	if len(checks) > 0 {
+		b.WriteString("\n\n🎯 Coverage targets:\n  ")
+		b.WriteString(strings.Join(checks, "\n  "))
+	}
+	result.Output = strings.TrimPrefix(b.String(), "\n\n")
+}
@@ -0,0 +1,188 @@
+package runner
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// defaultFuzzTime is the fuzzing budget when the exercise TOML doesn't set one
+const defaultFuzzTime = "10s"
+
+// crasherLine matches go test's report of where it saved a failing fuzz input
+var crasherLine = regexp.MustCompile(`Failing input written to (\S+)`)
+
+// FuzzCrasher is a failing input found by the fuzzer
+type FuzzCrasher struct {
+	Path  string `json:"path"`  // Corpus file, relative to the exercise directory
+	Input string `json:"input"` // Corpus file contents in "go test fuzz v1" encoding
+}
+
+// checkFuzzTarget verifies that a test file declares the fuzz target and that the
+// function passed to f.Fuzz calls the function under test.
+func checkFuzzTarget(testFiles []string, target, function string) error {
+	fset := token.NewFileSet()
+	for _, path := range testFiles {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.Name != target || fn.Body == nil {
+				continue
+			}
+
+			body := fuzzBody(fn)
+			if body == nil {
+				return fmt.Errorf("%s never calls f.Fuzz with a fuzz function", target)
+			}
+			if function != "" && !callsFunction(body, function) {
+				return fmt.Errorf("the fuzz function in %s never calls %s, so nothing is being fuzzed", target, function)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("fuzz target %s not found; declare func %s(f *testing.F) in the test file", target, target)
+}
+
+// fuzzBody returns the body of the function literal passed to f.Fuzz inside fn
+func fuzzBody(fn *ast.FuncDecl) *ast.BlockStmt {
+	var body *ast.BlockStmt
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || body != nil {
+			return body == nil
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Fuzz" || len(call.Args) != 1 {
+			return true
+		}
+		if lit, ok := call.Args[0].(*ast.FuncLit); ok {
+			body = lit.Body
+		}
+		return true
+	})
+	return body
+}
+
+// callsFunction reports whether node contains a call to name, either directly or as pkg.name / recv.name
+func callsFunction(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			found = found || fun.Name == name
+		case *ast.SelectorExpr:
+			found = found || fun.Sel.Name == name
+		}
+		return !found
+	})
+	return found
+}
+
+// fuzzCrashers reads the failing inputs go test reported in its output
+func fuzzCrashers(exerciseDir, output string) []FuzzCrasher {
+	var crashers []FuzzCrasher
+	for _, m := range crasherLine.FindAllStringSubmatch(output, -1) {
+		crasher := FuzzCrasher{Path: m[1]}
+		if data, err := os.ReadFile(filepath.Join(exerciseDir, m[1])); err == nil {
+			crasher.Input = strings.TrimSpace(string(data))
+		}
+		crashers = append(crashers, crasher)
+	}
+	return crashers
+}
+
+// testFiles returns the exercise's _test.go files
+func testFiles(ex *exercise.Exercise) []string {
+	var files []string
+	for _, target := range testTargets(ex) {
+		if strings.HasSuffix(target, "_test.go") {
+			files = append(files, target)
+		}
+	}
+	if ex.IsPackage() {
+		for _, file := range ex.Files {
+			if strings.HasSuffix(file, "_test.go") {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// runFuzz runs fuzz mode and fills in the result
+func (r *Runner) runFuzz(exerciseDir string, ex *exercise.Exercise, result *Result) {
+	cfg := ex.Validation.Fuzz
+	if cfg.Target == "" {
+		result.Output = "❌ No fuzz target specified. Set target under [validation.fuzz]."
+		return
+	}
+
+	if err := checkFuzzTarget(testFiles(ex), cfg.Target, cfg.Function); err != nil {
+		result.Output = "❌ " + err.Error()
+		return
+	}
+
+	// Fuzzing builds an instrumented binary and spawns its own workers, which the sandbox can't contain.
+	if mode := r.sandboxMode(ex); mode != SandboxNone {
+		result.Error = fmt.Sprintf("fuzz mode can't run under sandbox mode %q", mode)
+		return
+	}
+
+	fuzztime := cfg.Fuzztime
+	if fuzztime == "" {
+		fuzztime = defaultFuzzTime
+	}
+
+	// -run selects the target's seed corpus; -fuzz then mutates from it.
+	pattern := "^" + regexp.QuoteMeta(cfg.Target) + "$"
+	args := append([]string{"-run=" + pattern, "-fuzz=" + pattern, "-fuzztime=" + fuzztime}, testTargets(ex)...)
+	success, output, err := r.runGoCommand(exerciseDir, "test", args...)
+
+	result.Validation.FuzzSuccess = success
+	result.Validation.FuzzOutput = output
+	if err != nil {
+		result.Error = fmt.Sprintf("Fuzz command failed: %v", err)
+		result.Output = output
+		return
+	}
+
+	if success {
+		result.Success = true
+		result.Output = fmt.Sprintf("🐛 %s ran for %s without finding a failing input.", cfg.Target, fuzztime)
+		return
+	}
+
+	crashers := fuzzCrashers(exerciseDir, output)
+	result.Validation.FuzzCrashers = crashers
+	if len(crashers) == 0 {
+		result.Output = output
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "💥 %s found a failing input:\n", cfg.Target)
+	for _, crasher := range crashers {
+		fmt.Fprintf(&b, "\n  %s\n", crasher.Path)
+		for _, line := range strings.Split(crasher.Input, "\n") {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	b.WriteString("\nThe input is now part of the seed corpus, so it's re-checked on every run until fixed.\n\n")
+	b.WriteString(output)
+	result.Output = b.String()
+}
@@ -0,0 +1,251 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Test statuses reported in TestResult.Status
+const (
+	TestPass = "pass"
+	TestFail = "fail"
+	TestSkip = "skip"
+)
+
+// testEvent is one line of `go test -json` (test2json) output
+type testEvent struct {
+	Action     string  `json:"Action"`
+	Package    string  `json:"Package"`
+	Test       string  `json:"Test"`
+	Elapsed    float64 `json:"Elapsed"`
+	Output     string  `json:"Output"`
+	OutputType string  `json:"OutputType"` // "frame" for === RUN / --- PASS lines (Go 1.24+)
+}
+
+// TestResult is the outcome of a single test, with its subtests nested beneath it
+type TestResult struct {
+	Name     string        `json:"name"` // Full name, e.g. TestAdd/negative_numbers
+	Package  string        `json:"package,omitempty"`
+	Status   string        `json:"status"`
+	Elapsed  time.Duration `json:"elapsed"`
+	Output   string        `json:"output,omitempty"` // What the test itself logged, without framing lines
+	Subtests []*TestResult `json:"subtests,omitempty"`
+}
+
+// ShortName returns the last segment of the test name, the part a subtest was given in t.Run.
+// Package rows keep their full import path.
+func (t *TestResult) ShortName() string {
+	if t.Name == t.Package {
+		return t.Name
+	}
+	if i := strings.LastIndex(t.Name, "/"); i >= 0 {
+		return t.Name[i+1:]
+	}
+	return t.Name
+}
+
+// FirstFailure returns the deepest failing test along the first failing branch, or nil
+func FirstFailure(tests []*TestResult) *TestResult {
+	for _, test := range tests {
+		if test.Status != TestFail {
+			continue
+		}
+		if sub := FirstFailure(test.Subtests); sub != nil {
+			return sub
+		}
+		return test
+	}
+	return nil
+}
+
+// parseTestEvents turns a `go test -json` stream into a tree of test results.
+// Lines that aren't test events (build errors, panics printed before the
+// stream starts) and package-level output are returned as extra output.
+// Tests of several packages are grouped under a row per package.
+func parseTestEvents(stream string) ([]*TestResult, string) {
+	var roots []*TestResult
+	byName := make(map[string]*TestResult)
+	var extra strings.Builder
+
+	for _, line := range strings.Split(stream, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var ev testEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			extra.WriteString(line + "\n")
+			continue
+		}
+
+		switch ev.Action {
+		case "build-output":
+			extra.WriteString(ev.Output)
+			continue
+		case "start", "build-fail":
+			continue
+		}
+
+		if ev.Test == "" {
+			// Package-level output: keep panics and build failures, drop the PASS/FAIL summary
+			if ev.Action == "output" && !isPackageSummary(ev.Output) {
+				extra.WriteString(ev.Output)
+			}
+			continue
+		}
+
+		// Packages may have tests of the same name
+		key := ev.Package + "." + ev.Test
+		test := byName[key]
+		if test == nil {
+			test = &TestResult{Name: ev.Test, Package: ev.Package}
+			byName[key] = test
+
+			if i := strings.LastIndex(ev.Test, "/"); i >= 0 && byName[ev.Package+"."+ev.Test[:i]] != nil {
+				parent := byName[ev.Package+"."+ev.Test[:i]]
+				parent.Subtests = append(parent.Subtests, test)
+			} else {
+				roots = append(roots, test)
+			}
+		}
+
+		switch ev.Action {
+		case "output":
+			if ev.OutputType != "frame" && !isFrameLine(ev.Output) {
+				test.Output += ev.Output
+			}
+		case TestPass, TestFail, TestSkip:
+			test.Status = ev.Action
+			test.Elapsed = time.Duration(ev.Elapsed * float64(time.Second))
+		}
+	}
+
+	// Tests that never reported a result were cut off, e.g. by a panic or timeout
+	for _, test := range byName {
+		if test.Status == "" {
+			test.Status = TestFail
+		}
+	}
+
+	return groupByPackage(roots), strings.TrimSpace(extra.String())
+}
+
+// groupByPackage nests tests under a row per package when they come from more than
+// one, as when a package exercise runs ./...
+func groupByPackage(tests []*TestResult) []*TestResult {
+	var packages []*TestResult
+	byPackage := make(map[string]*TestResult)
+	for _, test := range tests {
+		pkg := byPackage[test.Package]
+		if pkg == nil {
+			pkg = &TestResult{Name: test.Package, Package: test.Package, Status: TestSkip}
+			byPackage[test.Package] = pkg
+			packages = append(packages, pkg)
+		}
+		pkg.Subtests = append(pkg.Subtests, test)
+		pkg.Elapsed += test.Elapsed
+		switch {
+		case test.Status == TestFail:
+			pkg.Status = TestFail
+		case test.Status == TestPass && pkg.Status == TestSkip:
+			pkg.Status = TestPass
+		}
+	}
+
+	if len(packages) < 2 {
+		return tests
+	}
+	return packages
+}
+
+// isFrameLine reports whether a line is test framework chatter rather than test output.
+// Go 1.24+ marks these with OutputType "frame"; older toolchains need the prefixes.
+func isFrameLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME", "--- PASS", "--- FAIL", "--- SKIP"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPackageSummary(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "PASS" || trimmed == "FAIL" ||
+		strings.HasPrefix(trimmed, "ok  \t") || strings.HasPrefix(trimmed, "ok \t") ||
+		strings.HasPrefix(trimmed, "FAIL\t") || strings.HasPrefix(trimmed, "coverage:")
+}
+
+// countTests tallies leaf tests, so a table test counts each of its cases
+func countTests(tests []*TestResult) (passed, failed, skipped int) {
+	for _, test := range tests {
+		if len(test.Subtests) > 0 {
+			p, f, s := countTests(test.Subtests)
+			passed, failed, skipped = passed+p, failed+f, skipped+s
+			continue
+		}
+		switch test.Status {
+		case TestPass:
+			passed++
+		case TestFail:
+			failed++
+		case TestSkip:
+			skipped++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// formatTestResults renders the test tree as a checklist with failing output shown inline
+func formatTestResults(tests []*TestResult, extra string) string {
+	passed, failed, skipped := countTests(tests)
+	total := passed + failed + skipped
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🧪 %d/%d tests passed", passed, total)
+	if skipped > 0 {
+		fmt.Fprintf(&b, " (%d skipped)", skipped)
+	}
+	b.WriteString("\n")
+
+	for _, test := range tests {
+		writeTestResult(&b, test, 1)
+	}
+
+	if extra != "" {
+		b.WriteString("\n" + extra + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeTestResult(b *strings.Builder, test *TestResult, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(b, "%s%s %s (%.2fs)\n", indent, TestStatusIcon(test.Status), test.ShortName(), test.Elapsed.Seconds())
+
+	if test.Status == TestFail && strings.TrimSpace(test.Output) != "" {
+		for _, line := range strings.Split(strings.TrimRight(test.Output, "\n"), "\n") {
+			if strings.TrimSpace(line) != "" {
+				fmt.Fprintf(b, "%s    %s\n", indent, strings.TrimSpace(line))
+			}
+		}
+	}
+
+	for _, sub := range test.Subtests {
+		writeTestResult(b, sub, depth+1)
+	}
+}
+
+// TestStatusIcon returns the checklist marker for a test status
+func TestStatusIcon(status string) string {
+	switch status {
+	case TestPass:
+		return "✅"
+	case TestSkip:
+		return "⏭️"
+	default:
+		return "❌"
+	}
+}
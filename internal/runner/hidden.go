@@ -0,0 +1,202 @@
+package runner
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// How much of a hidden test failure the learner gets to see
+const (
+	HiddenDetailSummary = "summary" // Only how many hidden tests failed
+	HiddenDetailNames   = "names"   // Which hidden tests failed, without their output
+	HiddenDetailFull    = "full"    // Names and output, like visible tests
+)
+
+// readHiddenTest returns a hidden test's contents, preferring the embedded copy
+// so that edits to the solutions directory can't change what the learner is graded on.
+func (r *Runner) readHiddenTest(test exercise.HiddenTest) ([]byte, error) {
+	if r.Content != nil {
+		if data, err := fs.ReadFile(r.Content, test.Embedded); err == nil {
+			return data, nil
+		}
+	}
+	data, err := os.ReadFile(test.Path)
+	if err != nil {
+		return nil, fmt.Errorf("hidden test %s not found", test.Name)
+	}
+	return data, nil
+}
+
+// stageBuildDir creates a private build directory under the working directory holding a
+// copy of the exercise module's go.mod and go.sum. It returns the directory that mirrors
+// exerciseDir inside it, where the caller copies the files to build, and a cleanup function.
+func (r *Runner) stageBuildDir(exerciseDir, pattern string) (string, func(), error) {
+	moduleRoot := r.findModuleRoot(exerciseDir)
+	if moduleRoot == "" {
+		return "", nil, fmt.Errorf("no go.mod found for %s", exerciseDir)
+	}
+	rel, err := filepath.Rel(moduleRoot, exerciseDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	base := filepath.Join(r.workingDir, privateBuildRoot)
+	if err := os.MkdirAll(base, 0o755); err != nil {
+		return "", nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	root, err := os.MkdirTemp(base, pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(root) }
+
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filepath.Join(moduleRoot, name))
+		if os.IsNotExist(err) && name == "go.sum" {
+			continue
+		}
+		if err == nil {
+			err = os.WriteFile(filepath.Join(root, name), data, 0o644)
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+
+	dst := filepath.Join(root, rel)
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dst, cleanup, nil
+}
+
+// copyExerciseFiles copies the exercise's files into dst, keeping their layout relative
+// to the work dir. Package exercises are copied whole; _test.go files only when withTests is set.
+func copyExerciseFiles(ex *exercise.Exercise, dst string, withTests bool) error {
+	var files []string
+	if ex.IsPackage() {
+		err := filepath.WalkDir(ex.WorkDir(), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Base(path) == "go.mod" {
+				return err
+			}
+			files = append(files, path)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy exercise: %w", err)
+		}
+	} else {
+		files = append(ex.SourceFiles(), testFiles(ex)...)
+	}
+
+	for _, file := range files {
+		if !withTests && strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		rel, err := filepath.Rel(ex.WorkDir(), file)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(file, filepath.Join(dst, rel)); err != nil {
+			return fmt.Errorf("failed to copy exercise: %w", err)
+		}
+	}
+	return nil
+}
+
+// stageHiddenTests builds a private directory holding the learner's non-test sources
+// and the hidden tests. Learner tests stay behind: they may have been edited to pass,
+// or clash with hidden test names.
+func (r *Runner) stageHiddenTests(exerciseDir string, ex *exercise.Exercise) (string, func(), error) {
+	dst, cleanup, err := r.stageBuildDir(exerciseDir, "hidden-*")
+	if err != nil {
+		return "", nil, err
+	}
+	if err := copyExerciseFiles(ex, dst, false); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	for _, test := range ex.HiddenTests {
+		data, err := r.readHiddenTest(test)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dst, test.Name), data, 0o644)
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("failed to stage hidden test %s: %w", test.Name, err)
+		}
+	}
+	return dst, cleanup, nil
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// runHiddenTests runs the exercise's hidden tests against the learner's code and
+// trims the report to the exercise's hidden_detail level.
+func (r *Runner) runHiddenTests(exerciseDir string, ex *exercise.Exercise, result *Result) (bool, string, error) {
+	buildDir, cleanup, err := r.stageHiddenTests(exerciseDir, ex)
+	if err != nil {
+		return false, "", err
+	}
+	defer cleanup()
+
+	target := "."
+	if ex.IsPackage() {
+		target = "./..."
+	}
+	success, output, err := r.runTests(buildDir, ex, []string{target}, result)
+	if err != nil {
+		return success, output, err
+	}
+	return success, hiddenReport(ex.Validation.HiddenDetail, success, output, result), nil
+}
+
+// hiddenReport rewrites a test report according to the detail level. Build errors are
+// always shown, since they point at the learner's own code.
+func hiddenReport(detail string, success bool, output string, result *Result) string {
+	tests := result.Validation.Tests
+	if len(tests) == 0 {
+		return output
+	}
+
+	passed, failed, skipped := countTests(tests)
+	total := passed + failed + skipped
+	if success {
+		return fmt.Sprintf("🔒 All %d hidden tests passed.", total)
+	}
+
+	switch detail {
+	case HiddenDetailFull:
+		return "🔒 Hidden tests:\n" + output
+	case HiddenDetailSummary:
+		result.Validation.Tests = nil
+		return fmt.Sprintf("🔒 %d of %d hidden tests failed.", failed, total)
+	default:
+		stripTestOutput(tests)
+		return "🔒 Hidden tests:\n" + formatTestResults(tests, "")
+	}
+}
+
+func stripTestOutput(tests []*TestResult) {
+	for _, test := range tests {
+		test.Output = ""
+		stripTestOutput(test.Subtests)
+	}
+}
@@ -0,0 +1,386 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// Mutation operators a mutation-mode exercise can enable
+const (
+	MutateComparison = "comparison" // < ↔ <=, > ↔ >=, == ↔ !=
+	MutateArithmetic = "arithmetic" // + ↔ -, * ↔ /
+	MutateBoolean    = "boolean"    // && ↔ ||
+	MutateConstant   = "constant"   // Integer literals off by one
+	MutateReturn     = "return"     // Returns inside if blocks removed
+)
+
+// Defaults for mutation mode when the exercise TOML leaves them out
+const (
+	defaultMutationThreshold = 0.8
+	defaultMaxMutants        = 40
+	minMutantTimeout         = 5 * time.Second
+)
+
+var allMutationOperators = []string{MutateComparison, MutateArithmetic, MutateBoolean, MutateConstant, MutateReturn}
+
+// operatorSwaps maps each mutable binary operator to its replacement and operator class
+var operatorSwaps = map[token.Token]struct {
+	to    token.Token
+	class string
+}{
+	token.LSS:  {token.LEQ, MutateComparison},
+	token.LEQ:  {token.LSS, MutateComparison},
+	token.GTR:  {token.GEQ, MutateComparison},
+	token.GEQ:  {token.GTR, MutateComparison},
+	token.EQL:  {token.NEQ, MutateComparison},
+	token.NEQ:  {token.EQL, MutateComparison},
+	token.ADD:  {token.SUB, MutateArithmetic},
+	token.SUB:  {token.ADD, MutateArithmetic},
+	token.MUL:  {token.QUO, MutateArithmetic},
+	token.QUO:  {token.MUL, MutateArithmetic},
+	token.LAND: {token.LOR, MutateBoolean},
+	token.LOR:  {token.LAND, MutateBoolean},
+}
+
+// Mutant is one small change to the implementation, and whether the learner's tests caught it
+type Mutant struct {
+	Operator string `json:"operator"`
+	Function string `json:"function"`
+	Line     int    `json:"line"`
+	Change   string `json:"change"` // e.g. "x > max → x >= max"
+	Killed   bool   `json:"killed"`
+}
+
+// mutation is a Mutant together with the AST edit that produces it
+type mutation struct {
+	Mutant
+	apply  func()
+	revert func()
+}
+
+// findMutations collects the mutations of the enabled operators in every function of
+// file except main and init, which tests don't call.
+func findMutations(fset *token.FileSet, file *ast.File, operators []string) []*mutation {
+	enabled := make(map[string]bool, len(operators))
+	for _, op := range operators {
+		enabled[op] = true
+	}
+
+	var mutations []*mutation
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || (fn.Recv == nil && (fn.Name.Name == "main" || fn.Name.Name == "init")) {
+			continue
+		}
+		name := funcName(fn)
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			var m *mutation
+			switch node := n.(type) {
+			case *ast.BinaryExpr:
+				m = binaryMutation(node)
+			case *ast.BasicLit:
+				m = constantMutation(node)
+			case *ast.IfStmt:
+				for i, stmt := range node.Body.List {
+					if _, ok := stmt.(*ast.ReturnStmt); ok && enabled[MutateReturn] {
+						mutations = append(mutations, returnMutation(fset, node.Body, i, name))
+					}
+				}
+			}
+			if m != nil && enabled[m.Operator] {
+				m.Function = name
+				m.Line = fset.Position(n.Pos()).Line
+				m.Change = describeChange(fset, n, m)
+				mutations = append(mutations, m)
+			}
+			return true
+		})
+	}
+
+	sort.SliceStable(mutations, func(i, j int) bool { return mutations[i].Line < mutations[j].Line })
+	return mutations
+}
+
+func binaryMutation(expr *ast.BinaryExpr) *mutation {
+	swap, ok := operatorSwaps[expr.Op]
+	if !ok || (expr.Op == token.ADD && (isStringLit(expr.X) || isStringLit(expr.Y))) {
+		return nil
+	}
+	original := expr.Op
+	return &mutation{
+		Mutant: Mutant{Operator: swap.class},
+		apply:  func() { expr.Op = swap.to },
+		revert: func() { expr.Op = original },
+	}
+}
+
+func constantMutation(lit *ast.BasicLit) *mutation {
+	if lit.Kind != token.INT {
+		return nil
+	}
+	n, err := strconv.ParseInt(lit.Value, 0, 64)
+	if err != nil {
+		return nil
+	}
+	original := lit.Value
+	return &mutation{
+		Mutant: Mutant{Operator: MutateConstant},
+		apply:  func() { lit.Value = strconv.FormatInt(n+1, 10) },
+		revert: func() { lit.Value = original },
+	}
+}
+
+func returnMutation(fset *token.FileSet, body *ast.BlockStmt, i int, function string) *mutation {
+	original := body.List
+	removed := append(append([]ast.Stmt{}, original[:i]...), original[i+1:]...)
+	return &mutation{
+		Mutant: Mutant{
+			Operator: MutateReturn,
+			Function: function,
+			Line:     fset.Position(original[i].Pos()).Line,
+			Change:   "removed `" + renderNode(fset, original[i]) + "`",
+		},
+		apply:  func() { body.List = removed },
+		revert: func() { body.List = original },
+	}
+}
+
+func isStringLit(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+// describeChange renders the mutated node before and after the mutation
+func describeChange(fset *token.FileSet, n ast.Node, m *mutation) string {
+	before := renderNode(fset, n)
+	m.apply()
+	after := renderNode(fset, n)
+	m.revert()
+	return before + " → " + after
+}
+
+func renderNode(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, n); err != nil {
+		return "?"
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// sampleMutations spreads a cap of max mutations evenly over the file
+func sampleMutations(mutations []*mutation, max int) []*mutation {
+	if len(mutations) <= max {
+		return mutations
+	}
+	sampled := make([]*mutation, 0, max)
+	for i := 0; i < max; i++ {
+		sampled = append(sampled, mutations[i*len(mutations)/max])
+	}
+	return sampled
+}
+
+// mutationOperators returns the configured operators, validating their names
+func mutationOperators(cfg exercise.MutationConfig) ([]string, error) {
+	if len(cfg.Operators) == 0 {
+		return allMutationOperators, nil
+	}
+	for _, op := range cfg.Operators {
+		known := false
+		for _, candidate := range allMutationOperators {
+			known = known || op == candidate
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown mutation operator %q (want one of %s)", op, strings.Join(allMutationOperators, ", "))
+		}
+	}
+	return cfg.Operators, nil
+}
+
+// readMutationSource returns the solution's copy of the mutation target, preferring the
+// embedded copy as readHiddenTest does
+func (r *Runner) readMutationSource(ex *exercise.Exercise) ([]byte, error) {
+	if r.Content != nil {
+		if data, err := fs.ReadFile(r.Content, ex.MutationSource); err == nil {
+			return data, nil
+		}
+	}
+	data, err := os.ReadFile(ex.MutationSourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("solution copy of %s not found", ex.Validation.Mutation.Target)
+	}
+	return data, nil
+}
+
+// runMutation runs mutation mode: the learner's tests must pass against the implementation
+// and fail against enough of its mutants.
+func (r *Runner) runMutation(exerciseDir string, ex *exercise.Exercise, result *Result) {
+	cfg := ex.Validation.Mutation
+	if cfg.Target == "" {
+		result.Output = "❌ No mutation target specified. Set target under [validation.mutation]."
+		return
+	}
+	operators, err := mutationOperators(cfg)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	// Every mutant is a fresh build, which the sandbox's compile-once model doesn't fit.
+	// Only an exercise asking for the sandbox itself is an error; a machine-wide default
+	// is skipped with a note.
+	if mode := ex.Validation.Sandbox; mode != "" && mode != SandboxNone {
+		result.Error = fmt.Sprintf("mutation mode can't run under sandbox mode %q", mode)
+		return
+	}
+	if mode := r.sandboxMode(ex); mode != SandboxNone {
+		result.Validation.SandboxMode = mode
+		result.Validation.SandboxNotes = append(result.Validation.SandboxNotes,
+			"mutation mode builds every mutant afresh, so its tests ran without the sandbox")
+	}
+
+	dst, cleanup, err := r.stageBuildDir(exerciseDir, "mutants-*")
+	if err != nil {
+		result.Error = fmt.Sprintf("Mutation setup failed: %v", err)
+		return
+	}
+	defer cleanup()
+
+	targetPath := filepath.Join(dst, cfg.Target)
+	err = copyExerciseFiles(ex, dst, true)
+	if err == nil && ex.MutationSource != "" {
+		var data []byte
+		if data, err = r.readMutationSource(ex); err == nil {
+			err = os.WriteFile(targetPath, data, 0o644)
+		}
+	} else if err == nil {
+		err = copyFile(filepath.Join(ex.WorkDir(), cfg.Target), targetPath)
+	}
+	if err != nil {
+		result.Error = fmt.Sprintf("Mutation setup failed: %v", err)
+		return
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, targetPath, nil, parser.ParseComments)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to parse %s: %v", cfg.Target, err)
+		return
+	}
+
+	// The tests have to pass on the real implementation before mutants mean anything.
+	start := time.Now()
+	success, output, err := r.runGoCommand(dst, "test", ".")
+	if err != nil {
+		result.Error = fmt.Sprintf("Test command failed: %v", err)
+		result.Output = output
+		return
+	}
+	if !success {
+		result.Output = "❌ Your tests must pass against the original implementation before mutants are tried.\n\n" + output
+		return
+	}
+	mutantTimeout := max(10*time.Since(start), minMutantTimeout)
+
+	maxMutants := cfg.MaxMutants
+	if maxMutants <= 0 {
+		maxMutants = defaultMaxMutants
+	}
+	mutations := sampleMutations(findMutations(fset, file, operators), maxMutants)
+	var mutants []Mutant
+	skipped := 0
+	for _, m := range mutations {
+		m.apply()
+		var buf bytes.Buffer
+		err := format.Node(&buf, fset, file)
+		m.revert()
+		if err == nil {
+			err = os.WriteFile(targetPath, buf.Bytes(), 0o644)
+		}
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to write mutant: %v", err)
+			return
+		}
+
+		success, output, err := r.runGoCommand(dst, "test", "-failfast", "-timeout="+mutantTimeout.String(), ".")
+		if err != nil {
+			result.Error = fmt.Sprintf("Test command failed: %v", err)
+			return
+		}
+		if strings.Contains(output, "[build failed]") || strings.Contains(output, "[setup failed]") {
+			skipped++ // Not a valid program, e.g. a mutation that breaks a constant expression
+			continue
+		}
+		m.Killed = !success
+		mutants = append(mutants, m.Mutant)
+	}
+
+	threshold := cfg.Threshold
+	if threshold <= 0 {
+		threshold = defaultMutationThreshold
+	}
+	passed, report := mutationReport(mutants, skipped, threshold)
+	result.Validation.Mutants = mutants
+	result.Validation.MutationScore = mutationScore(mutants)
+	result.Validation.MutationSuccess = passed
+	result.Success = passed
+	result.Output = report
+}
+
+// mutationScore returns the fraction of mutants the tests killed
+func mutationScore(mutants []Mutant) float64 {
+	if len(mutants) == 0 {
+		return 0
+	}
+	killed := 0
+	for _, m := range mutants {
+		if m.Killed {
+			killed++
+		}
+	}
+	return float64(killed) / float64(len(mutants))
+}
+
+// mutationReport summarizes the mutation score and lists every surviving mutant
+func mutationReport(mutants []Mutant, skipped int, threshold float64) (bool, string) {
+	if len(mutants) == 0 {
+		return false, "❌ No mutants could be generated for the target."
+	}
+
+	score := mutationScore(mutants)
+	killed := int(score*float64(len(mutants)) + 0.5)
+	passed := score >= threshold
+
+	var b strings.Builder
+	icon := "✅"
+	if !passed {
+		icon = "❌"
+	}
+	fmt.Fprintf(&b, "%s 🧬 Mutation score: %d/%d mutants killed (%.0f%%, need %.0f%%)\n",
+		icon, killed, len(mutants), score*100, threshold*100)
+
+	if killed < len(mutants) {
+		b.WriteString("\nSurviving mutants (your tests still pass with these bugs):\n")
+		for _, m := range mutants {
+			if !m.Killed {
+				fmt.Fprintf(&b, "  🧟 %s (line %d, %s): %s\n", m.Function, m.Line, m.Operator, m.Change)
+			}
+		}
+	}
+	if skipped > 0 {
+		fmt.Fprintf(&b, "\n(%d mutants didn't compile and were skipped)\n", skipped)
+	}
+	return passed, strings.TrimSuffix(b.String(), "\n")
+}
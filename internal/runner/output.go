@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stonecharioteer/goforgo/internal/textdiff"
+)
+
+// Expected output comparison modes, selected via expected_output_mode in the exercise TOML.
+const (
+	OutputModeExact                = "exact"
+	OutputModeNormalizedWhitespace = "normalized_whitespace"
+	OutputModeLineSet              = "line_set"
+	OutputModeRegex                = "regex"
+	OutputModeJSON                 = "json"
+)
+
+// DiffLine is a single rendered line of a unified expected/actual diff
+type DiffLine = textdiff.Line
+
+// Terminal styles of unified diff lines, shared by the TUI and the CLI
+var (
+	DiffInsertStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#3fb950"))
+	DiffDeleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#f85149"))
+	DiffHunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#58a6ff")).Bold(true)
+)
+
+// ColorizeDiffLines styles unified diff lines: additions green, removals red, headers blue
+func ColorizeDiffLines(lines []string) []string {
+	styled := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "@@"):
+			styled[i] = DiffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			styled[i] = DiffInsertStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			styled[i] = DiffDeleteStyle.Render(line)
+		default:
+			styled[i] = line
+		}
+	}
+	return styled
+}
+
+// outputComparison is the outcome of comparing program output against expectations
+type outputComparison struct {
+	Match bool
+	Diff  []DiffLine
+}
+
+// compareOutput compares actual program output against the expected output using the given mode.
+// An empty mode behaves like exact.
+func compareOutput(mode, expected, actual string) (*outputComparison, error) {
+	expected = normalizeNewlines(expected)
+	actual = normalizeNewlines(actual)
+
+	switch mode {
+	case "", OutputModeExact:
+		exp := strings.TrimSpace(expected)
+		act := strings.TrimSpace(actual)
+		return compareLines(exp == act, splitLines(exp), splitLines(act), equalLines), nil
+
+	case OutputModeNormalizedWhitespace:
+		exp := normalizeWhitespace(expected)
+		act := normalizeWhitespace(actual)
+		return compareLines(reflect.DeepEqual(exp, act), exp, act, equalLines), nil
+
+	case OutputModeLineSet:
+		// Sort both sides so the diff only reports missing or unexpected lines,
+		// not ordering differences from concurrent output.
+		exp := normalizeWhitespace(expected)
+		act := normalizeWhitespace(actual)
+		sort.Strings(exp)
+		sort.Strings(act)
+		return compareLines(reflect.DeepEqual(exp, act), exp, act, equalLines), nil
+
+	case OutputModeRegex:
+		exp := splitLines(strings.TrimSpace(expected))
+		patterns := make([]*regexp.Regexp, len(exp))
+		for i, line := range exp {
+			re, err := regexp.Compile("^(?:" + strings.TrimSpace(line) + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid expected_output regex on line %d: %w", i+1, err)
+			}
+			patterns[i] = re
+		}
+		act := splitLines(strings.TrimSpace(actual))
+		match := len(exp) == len(act)
+		for i := 0; match && i < len(act); i++ {
+			match = patterns[i].MatchString(strings.TrimSpace(act[i]))
+		}
+		eq := func(i, j int, _, a string) bool {
+			return patterns[i].MatchString(strings.TrimSpace(a))
+		}
+		return compareLines(match, exp, act, eq), nil
+
+	case OutputModeJSON:
+		var expValue interface{}
+		if err := json.Unmarshal([]byte(expected), &expValue); err != nil {
+			return nil, fmt.Errorf("expected_output is not valid JSON: %w", err)
+		}
+		expPretty, _ := json.MarshalIndent(expValue, "", "  ")
+
+		var actValue interface{}
+		if err := json.Unmarshal([]byte(actual), &actValue); err != nil {
+			// Not JSON at all - diff against the raw output so the learner sees what was printed.
+			return compareLines(false, splitLines(string(expPretty)), splitLines(strings.TrimSpace(actual)), equalLines), nil
+		}
+		actPretty, _ := json.MarshalIndent(actValue, "", "  ")
+		return compareLines(reflect.DeepEqual(expValue, actValue), splitLines(string(expPretty)), splitLines(string(actPretty)), equalLines), nil
+
+	default:
+		return nil, fmt.Errorf("unknown expected_output_mode: %s", mode)
+	}
+}
+
+// compareLines wraps a match decision with a diff of the two line slices when they differ
+func compareLines(match bool, expected, actual []string, eq func(i, j int, e, a string) bool) *outputComparison {
+	if match {
+		return &outputComparison{Match: true}
+	}
+	return &outputComparison{Diff: textdiff.Unified(expected, actual, eq)}
+}
+
+func equalLines(_, _ int, e, a string) bool {
+	return e == a
+}
+
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// normalizeWhitespace collapses runs of whitespace within each line and drops blank lines
+func normalizeWhitespace(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		lines = append(lines, strings.Join(fields, " "))
+	}
+	return lines
+}
+
+// outputModeName returns the display name of a comparison mode, resolving the default
+func outputModeName(mode string) string {
+	if mode == "" {
+		return OutputModeExact
+	}
+	return mode
+}
@@ -0,0 +1,253 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+	"github.com/stonecharioteer/goforgo/internal/deps"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/textdiff"
+)
+
+// Quality gate levels, per kind of finding
+const (
+	QualityOff   = "off"   // Not checked
+	QualityWarn  = "warn"  // Reported, but the exercise can still pass
+	QualityBlock = "block" // The exercise fails until the finding is fixed
+)
+
+// QualityFinding is one quality gate finding in the learner's code
+type QualityFinding struct {
+	Tool     string `json:"tool"` // "gofmt", "vet" or "analyzers"
+	Blocking bool   `json:"blocking"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+// qualityLevels resolves the gofmt, vet and analyzer levels for an exercise, falling
+// back to the runner's machine-wide level for anything the exercise leaves unset.
+// An unrecognised machine-wide level turns the gate off.
+func (r *Runner) qualityLevels(ex *exercise.Exercise) (gofmt, vet, analyzers string) {
+	level := func(set string) string {
+		if set != "" {
+			return set
+		}
+		switch r.Quality {
+		case QualityWarn, QualityBlock:
+			return r.Quality
+		}
+		return QualityOff
+	}
+	q := ex.Validation.Quality
+	return level(q.Gofmt), level(q.Vet), level(q.Analyzers)
+}
+
+// qualityFiles returns the files the learner edits: every non-test source, plus the main
+// file when it is a test the learner writes.
+func qualityFiles(ex *exercise.Exercise) []string {
+	files := ex.SourceFiles()
+	if strings.HasSuffix(ex.FilePath, "_test.go") {
+		files = append(files, ex.FilePath)
+	}
+	return files
+}
+
+// FormatFile rewrites a Go file in place the way gofmt would. It reports whether the
+// file changed.
+func FormatFile(path string) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := format.Source(src)
+	if err != nil {
+		return false, fmt.Errorf("can't format %s: %w", filepath.Base(path), err)
+	}
+	if bytes.Equal(src, formatted) {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, formatted, info.Mode().Perm())
+}
+
+// gofmtFindings reports one finding per file gofmt would change, at the first changed
+// line, with the diff gofmt -d would print.
+func gofmtFindings(files []string) ([]QualityFinding, string, error) {
+	var findings []QualityFinding
+	var diffs []string
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, "", err
+		}
+		formatted, err := format.Source(src)
+		if err != nil {
+			return nil, "", fmt.Errorf("gofmt: %s: %w", filepath.Base(file), err)
+		}
+		if bytes.Equal(src, formatted) {
+			continue
+		}
+
+		before := strings.Split(string(src), "\n")
+		after := strings.Split(string(formatted), "\n")
+		diff := textdiff.Unified(before, after, nil)
+		line := 1
+		for line <= len(before) && line <= len(after) && before[line-1] == after[line-1] {
+			line++
+		}
+		if len(diff) >= 2 {
+			name := filepath.Base(file)
+			diff[0].Text, diff[1].Text = "--- "+name, "+++ "+name+" (gofmt)"
+		}
+
+		findings = append(findings, QualityFinding{
+			Tool:    "gofmt",
+			File:    file,
+			Line:    line,
+			Message: "file is not gofmt-formatted",
+		})
+		diffs = append(diffs, textdiff.Format(diff))
+	}
+	return findings, strings.Join(diffs, "\n"), nil
+}
+
+// diagnosticFindings converts analyzer diagnostics into quality findings
+func diagnosticFindings(tool string, diags []analysis.Diagnostic) []QualityFinding {
+	findings := make([]QualityFinding, 0, len(diags))
+	for _, d := range diags {
+		f := d.Finding()
+		findings = append(findings, QualityFinding{
+			Tool:    tool,
+			File:    f.Pos.Filename,
+			Line:    f.Pos.Line,
+			Column:  f.Pos.Column,
+			Message: f.Message,
+		})
+	}
+	return findings
+}
+
+// runQualityGate runs gofmt, go vet and the curated analyzers at their configured
+// levels and appends a report. Blocking findings fail the exercise; warnings don't.
+func (r *Runner) runQualityGate(exerciseDir string, ex *exercise.Exercise, result *Result) {
+	gofmtLevel, vetLevel, analyzersLevel := r.qualityLevels(ex)
+	if gofmtLevel == QualityOff && vetLevel == QualityOff && analyzersLevel == QualityOff {
+		return
+	}
+	targets := buildTargets(ex)
+	if len(targets) == 0 {
+		targets = testTargets(ex)
+	}
+
+	var findings []QualityFinding
+	var gofmtDiff string
+	add := func(level string, found []QualityFinding) {
+		for _, f := range found {
+			f.Blocking = level == QualityBlock
+			findings = append(findings, f)
+		}
+	}
+	fail := func(err error) {
+		result.Success = false
+		result.Error = fmt.Sprintf("Quality gate failed: %v", err)
+	}
+
+	if gofmtLevel != QualityOff {
+		found, diff, err := gofmtFindings(qualityFiles(ex))
+		if err != nil {
+			fail(err)
+			return
+		}
+		gofmtDiff = diff
+		add(gofmtLevel, found)
+	}
+
+	if vetLevel != QualityOff {
+		_, output, err := r.runGoCommand(exerciseDir, "vet", append([]string{"-json"}, targets...)...)
+		var diags []analysis.Diagnostic
+		if err == nil {
+			diags, err = analysis.ParseDiagnostics([]byte(output))
+		}
+		if err != nil {
+			fail(fmt.Errorf("go vet: %w", err))
+			return
+		}
+		add(vetLevel, diagnosticFindings("vet", diags))
+	}
+
+	if analyzersLevel != QualityOff {
+		diags, err := analysis.RunAnalyzers(exerciseDir, deps.GoEnv(r.workingDir), analysis.Curated(), targets...)
+		if err != nil {
+			fail(err)
+			return
+		}
+		add(analyzersLevel, diagnosticFindings("analyzers", diags))
+	}
+
+	findings = dedupeFindings(findings)
+	result.Validation.QualityFindings = findings
+	result.Validation.QualitySuccess = true
+	for _, f := range findings {
+		if f.Blocking {
+			result.Validation.QualitySuccess = false
+		}
+	}
+	if len(findings) == 0 {
+		return
+	}
+
+	report := formatQualityFindings(findings, gofmtDiff)
+	result.Validation.QualityOutput = report
+	result.Output = strings.TrimPrefix(result.Output+"\n\n"+report, "\n\n")
+	if !result.Validation.QualitySuccess {
+		result.Success = false
+	}
+}
+
+// dedupeFindings drops findings repeated by go vet and the curated analyzers, which
+// share some passes on newer toolchains. Blocking wins over a warning for the same finding.
+func dedupeFindings(findings []QualityFinding) []QualityFinding {
+	index := make(map[string]int, len(findings))
+	var unique []QualityFinding
+	for _, f := range findings {
+		key := fmt.Sprintf("%s:%d:%d:%s", f.File, f.Line, f.Column, f.Message)
+		if i, ok := index[key]; ok {
+			unique[i].Blocking = unique[i].Blocking || f.Blocking
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, f)
+	}
+	return unique
+}
+
+// formatQualityFindings renders blockers and warnings, followed by the gofmt diff
+func formatQualityFindings(findings []QualityFinding, gofmtDiff string) string {
+	var b strings.Builder
+	b.WriteString("🧹 Quality gate:\n")
+	for _, f := range findings {
+		icon := "⚠️ "
+		if f.Blocking {
+			icon = "❌"
+		}
+		pos := fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
+		if f.Column > 0 {
+			pos += fmt.Sprintf(":%d", f.Column)
+		}
+		fmt.Fprintf(&b, "  %s %s: %s: %s\n", icon, f.Tool, pos, f.Message)
+	}
+	if gofmtDiff != "" {
+		b.WriteString("\n" + gofmtDiff + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
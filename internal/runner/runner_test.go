@@ -1,12 +1,20 @@
 package runner
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	_ "github.com/stonecharioteer/goforgo/internal/checks" // Registers the static checks
 	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/textdiff"
 )
 
 func TestRunner_ValidateExercise_ExpectedOutput(t *testing.T) {
@@ -203,3 +211,1113 @@ func main() {
 		t.Error("Expected feedback for timeout")
 	}
 }
+
+func TestCompareOutput_Modes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		expected string
+		actual   string
+		match    bool
+	}{
+		{"ExactMatch", "", "Hello\nWorld", "Hello\nWorld\n", true},
+		{"ExactMismatch", OutputModeExact, "Hello\nWorld", "Hello\nGo", false},
+		{"NormalizedWhitespace", OutputModeNormalizedWhitespace, "a  b\n\nc", "  a b\nc  ", true},
+		{"LineSetReordered", OutputModeLineSet, "worker 1 done\nworker 2 done", "worker 2 done\nworker 1 done", true},
+		{"LineSetMissingLine", OutputModeLineSet, "worker 1 done\nworker 2 done", "worker 2 done", false},
+		{"RegexPerLine", OutputModeRegex, "took \\d+ms\nresult: [a-z]+", "took 42ms\nresult: ok", true},
+		{"RegexWrongLineCount", OutputModeRegex, "took \\d+ms", "took 42ms\nextra", false},
+		{"JSONEquivalent", OutputModeJSON, `{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, true},
+		{"JSONDifferent", OutputModeJSON, `{"a": 1}`, `{"a": 2}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison, err := compareOutput(tt.mode, tt.expected, tt.actual)
+			if err != nil {
+				t.Fatalf("compareOutput failed: %v", err)
+			}
+			if comparison.Match != tt.match {
+				t.Errorf("Expected match=%v, got %v (diff:\n%s)", tt.match, comparison.Match, textdiff.Format(comparison.Diff))
+			}
+			if !tt.match && len(comparison.Diff) == 0 {
+				t.Error("Expected a diff for mismatched output")
+			}
+		})
+	}
+
+	if _, err := compareOutput("fuzzy", "a", "a"); err == nil {
+		t.Error("Expected error for unknown expected_output_mode")
+	}
+}
+
+func TestRunner_RunCases(t *testing.T) {
+	tempDir := t.TempDir()
+
+	ex := &exercise.Exercise{
+		FilePath: filepath.Join(tempDir, "echo.go"),
+		Info: exercise.ExerciseInfo{
+			Name:     "echo",
+			Category: "test",
+		},
+		Validation: exercise.ExerciseValidation{
+			Mode:    "run",
+			Timeout: "30s",
+			Cases: []exercise.ValidationCase{
+				{Name: "args", Args: []string{"a", "b"}, ExpectedOutput: "args: a b"},
+				{Name: "stdin", Stdin: "from stdin\n", ExpectedOutput: "stdin: from stdin"},
+				{Name: "env", Env: map[string]string{"GREETING": "hi"}, ExpectedOutput: "env: hi"},
+			},
+		},
+	}
+
+	goContent := `package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		fmt.Println("args:", strings.Join(os.Args[1:], " "))
+		return
+	}
+	if greeting := os.Getenv("GREETING"); greeting != "" {
+		fmt.Println("env:", greeting)
+		return
+	}
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	fmt.Println("stdin:", strings.TrimSpace(line))
+}
+`
+	if err := os.WriteFile(ex.FilePath, []byte(goContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	runner := NewRunner(tempDir)
+	result, err := runner.RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected all cases to pass, got output:\n%s", result.Output)
+	}
+	if len(result.Validation.CaseResults) != 3 {
+		t.Fatalf("Expected 3 case results, got %d", len(result.Validation.CaseResults))
+	}
+
+	// Break one case and make sure it is reported by name.
+	ex.Validation.Cases[1].ExpectedOutput = "stdin: something else"
+	result, err = runner.RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise failed: %v", err)
+	}
+	if result.Success {
+		t.Fatal("Expected failure when a case output does not match")
+	}
+	if result.Validation.CaseResults[1].Success || !result.Validation.CaseResults[0].Success {
+		t.Errorf("Unexpected case results: %+v", result.Validation.CaseResults)
+	}
+	if !strings.Contains(result.Output, `Case "stdin" failed`) {
+		t.Errorf("Expected output to name the failing case, got:\n%s", result.Output)
+	}
+}
+
+func TestSandboxModes(t *testing.T) {
+	// The exercise loader accepts exactly the modes the runner knows
+	modes := []string{SandboxNone}
+	for mode := range sandboxProfiles {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	accepted := slices.Sorted(slices.Values(exercise.SandboxModes))
+	if !slices.Equal(modes, accepted) {
+		t.Errorf("Runner sandbox modes %v don't match exercise.SandboxModes %v", modes, accepted)
+	}
+}
+
+func TestRunner_Sandbox(t *testing.T) {
+	if !sandboxSupported {
+		t.Skip("sandboxed execution is only supported on Linux")
+	}
+
+	tempDir := t.TempDir()
+
+	ex := &exercise.Exercise{
+		FilePath: filepath.Join(tempDir, "sandboxed.go"),
+		Info: exercise.ExerciseInfo{
+			Name:     "sandboxed",
+			Category: "test",
+		},
+		Validation: exercise.ExerciseValidation{
+			Mode:           "run",
+			Timeout:        "30s",
+			Sandbox:        SandboxStrict,
+			ExpectedOutput: "home is private",
+			Env:            map[string]string{"EXTRA": "1"},
+		},
+	}
+
+	t.Run("ScrubbedEnvironment", func(t *testing.T) {
+		goContent := `package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	if strings.Contains(os.Getenv("HOME"), "goforgo-sandbox") && os.Getenv("EXTRA") == "1" {
+		fmt.Println("home is private")
+	}
+}
+`
+		if err := os.WriteFile(ex.FilePath, []byte(goContent), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		result, err := NewRunner(tempDir).RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise failed: %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("Expected success, got output:\n%s\nerror: %s", result.Output, result.Error)
+		}
+		if result.Validation.SandboxMode != SandboxStrict {
+			t.Errorf("Expected sandbox mode %q, got %q", SandboxStrict, result.Validation.SandboxMode)
+		}
+	})
+
+	t.Run("MemoryLimit", func(t *testing.T) {
+		goContent := `package main
+
+import "fmt"
+
+func main() {
+	var chunks [][]byte
+	for i := 0; i < 64; i++ {
+		chunk := make([]byte, 16<<20)
+		chunk[0] = 1
+		chunks = append(chunks, chunk)
+	}
+	fmt.Println("home is private", len(chunks))
+}
+`
+		if err := os.WriteFile(ex.FilePath, []byte(goContent), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		result, err := NewRunner(tempDir).RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise failed: %v", err)
+		}
+		if result.Success {
+			t.Fatal("Expected the memory hog to be stopped by the sandbox")
+		}
+		if !strings.Contains(result.Validation.KilledReason, "memory") {
+			t.Errorf("Expected a memory kill reason, got %q (output:\n%s)", result.Validation.KilledReason, result.Output)
+		}
+	})
+
+	t.Run("ProcessLimit", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root is exempt from process limits")
+		}
+		goContent := `package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+func main() {
+	for i := 0; i < 1000; i++ {
+		cmd := exec.Command("sleep", "2")
+		if err := cmd.Start(); err != nil {
+			if errors.Is(err, syscall.EAGAIN) {
+				fmt.Println("home is private")
+			} else {
+				fmt.Println(err)
+			}
+			return
+		}
+		// Don't hold a pidfd per child, so the open file limit isn't what stops it
+		_ = cmd.Process.Release()
+	}
+	fmt.Println("unlimited")
+}
+`
+		if err := os.WriteFile(ex.FilePath, []byte(goContent), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		// Both modes cap processes, with or without a private user namespace
+		for _, mode := range []string{SandboxBasic, SandboxStrict} {
+			ex.Validation.Sandbox = mode
+			result, err := NewRunner(tempDir).RunExercise(ex)
+			if err != nil {
+				t.Fatalf("RunExercise failed: %v", err)
+			}
+			if !result.Success {
+				t.Errorf("Expected the %s sandbox to cap processes, got output:\n%s", mode, result.Output)
+			}
+		}
+		ex.Validation.Sandbox = SandboxStrict
+	})
+}
+
+func TestFindTodos(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "todos.go")
+
+	goContent := `package main
+
+import "fmt"
+
+// TODO: implement greeting
+func main() {
+	todoList := []string{"TODO in a string literal"}
+	fmt.Println(todoList) // TODO(required): print each item
+	/* a plain block comment
+	   todo: lowercase marker in a block */
+}
+`
+	if err := os.WriteFile(filePath, []byte(goContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	items, err := findTodos(filePath)
+	if err != nil {
+		t.Fatalf("findTodos failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 TODO comments, got %d: %+v", len(items), items)
+	}
+
+	wantPositions := []struct {
+		line, column int
+		required     bool
+	}{
+		{5, 4, false},
+		{8, 27, true},
+		{10, 5, false},
+	}
+	for i, want := range wantPositions {
+		if items[i].Line != want.line || items[i].Column != want.column || items[i].Required != want.required {
+			t.Errorf("Item %d: expected %d:%d required=%v, got %d:%d required=%v",
+				i, want.line, want.column, want.required, items[i].Line, items[i].Column, items[i].Required)
+		}
+	}
+
+	tests := []struct {
+		policy   string
+		blocking bool
+	}{
+		{"", true},
+		{TodoPolicyAll, true},
+		{TodoPolicyRequired, true},
+		{TodoPolicyNone, false},
+	}
+	for _, tt := range tests {
+		applied, err := applyTodoPolicy(append([]TodoItem(nil), items...), tt.policy)
+		if err != nil {
+			t.Fatalf("applyTodoPolicy(%q) failed: %v", tt.policy, err)
+		}
+		if blocking, _ := formatTodos(applied); blocking != tt.blocking {
+			t.Errorf("Policy %q: expected blocking=%v, got %v", tt.policy, tt.blocking, blocking)
+		}
+	}
+
+	// Only the plain TODOs remain once the required one is done.
+	optionalOnly, _ := applyTodoPolicy([]TodoItem{items[0], items[2]}, TodoPolicyRequired)
+	if blocking, _ := formatTodos(optionalOnly); blocking {
+		t.Error("Expected plain TODOs to be informational under the required policy")
+	}
+}
+
+func TestCheckForTodoComments_TestFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+	done := write("done.go", "package main\n\nfunc Done() {}\n")
+	todoTest := write("done_test.go", "package main\n\nimport \"testing\"\n\nfunc TestDone(t *testing.T) {\n\t// TODO: finish this\n}\n")
+
+	tests := []struct {
+		name     string
+		ex       *exercise.Exercise
+		blocking bool
+	}{
+		{"test file as the main file", &exercise.Exercise{FilePath: todoTest, Files: []string{todoTest}}, true},
+		{"learner test in files", &exercise.Exercise{FilePath: done, Files: []string{done, todoTest}, TestFilePath: todoTest}, true},
+		{"provided test", &exercise.Exercise{FilePath: done, Files: []string{done}, TestFilePath: todoTest}, false},
+	}
+
+	r := NewRunner(tempDir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocking, message, _ := r.checkForTodoComments(tt.ex)
+			if blocking != tt.blocking {
+				t.Errorf("Expected blocking=%v, got %v (%s)", tt.blocking, blocking, message)
+			}
+		})
+	}
+}
+
+func TestRunner_PackageExercise(t *testing.T) {
+	tempDir := t.TempDir()
+	pkgDir := filepath.Join(tempDir, "layout")
+	libDir := filepath.Join(pkgDir, "internal", "greet")
+	if err := os.MkdirAll(libDir, 0755); err != nil {
+		t.Fatalf("Failed to create package dirs: %v", err)
+	}
+
+	mainContent := `package main
+
+import (
+	"fmt"
+
+	"goforgo/test/layout/internal/greet"
+)
+
+func main() {
+	fmt.Println(greet.Hello("gopher"))
+}`
+	libContent := `package greet
+
+func Hello(name string) string {
+	return "hello, " + name
+}`
+	if err := os.WriteFile(filepath.Join(pkgDir, "main.go"), []byte(mainContent), 0644); err != nil {
+		t.Fatalf("Failed to write main.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "greet.go"), []byte(libContent), 0644); err != nil {
+		t.Fatalf("Failed to write greet.go: %v", err)
+	}
+
+	ex := &exercise.Exercise{
+		FilePath: filepath.Join(pkgDir, "main.go"),
+		Dir:      pkgDir,
+		Files:    []string{filepath.Join(pkgDir, "main.go"), filepath.Join(libDir, "greet.go")},
+		Info: exercise.ExerciseInfo{
+			Name:     "layout",
+			Category: "test",
+		},
+		Validation: exercise.ExerciseValidation{
+			Mode:           "run",
+			ExpectedOutput: "hello, gopher",
+			RequiredFiles:  []string{"internal/greet/greet.go"},
+			Timeout:        "30s",
+		},
+	}
+
+	r := NewRunner(tempDir)
+
+	t.Run("BuildsWholePackage", func(t *testing.T) {
+		result, err := r.RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("expected package exercise to pass, got output: %s", result.Output)
+		}
+	})
+
+	t.Run("MissingRequiredFile", func(t *testing.T) {
+		missing := *ex
+		missing.Validation.RequiredFiles = []string{"internal/store/store.go"}
+		result, err := r.RunExercise(&missing)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		if result.Success || !strings.Contains(result.Output, "internal/store/store.go") {
+			t.Errorf("expected missing required file to be reported, got: %s", result.Output)
+		}
+	})
+}
+
+func TestRunner_GoVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	exerciseDir := filepath.Join(tempDir, "exercises", "test")
+	if err := os.MkdirAll(exerciseDir, 0755); err != nil {
+		t.Fatalf("Failed to create exercise dir: %v", err)
+	}
+	filePath := filepath.Join(exerciseDir, "iter.go")
+	if err := os.WriteFile(filePath, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write exercise file: %v", err)
+	}
+
+	r := NewRunner(tempDir)
+
+	t.Run("Unavailable", func(t *testing.T) {
+		ex := &exercise.Exercise{
+			FilePath:    filePath,
+			Unavailable: "requires Go 1.99 or newer (installed: go1.24.5)",
+			Info:        exercise.ExerciseInfo{Name: "iter", Category: "test"},
+			Validation:  exercise.ExerciseValidation{Mode: "build"},
+		}
+		result, err := r.RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		if result.Success || !strings.Contains(result.Output, "unavailable") {
+			t.Errorf("expected unavailable result, got: %s", result.Output)
+		}
+	})
+
+	t.Run("StagesWorkspaceDirective", func(t *testing.T) {
+		workspace := filepath.Join(tempDir, "exercises")
+		goMod := filepath.Join(workspace, "go.mod")
+		if err := os.WriteFile(goMod, []byte("module goforgo\n\ngo 1.21\n"), 0644); err != nil {
+			t.Fatalf("Failed to write go.mod: %v", err)
+		}
+		// Ranging over a function needs Go 1.23
+		rangeFunc := "package main\n\nfunc main() {\n\tfor range func(yield func() bool) {} {\n\t}\n}\n"
+		if err := os.WriteFile(filePath, []byte(rangeFunc), 0644); err != nil {
+			t.Fatalf("Failed to write exercise file: %v", err)
+		}
+
+		ex := &exercise.Exercise{
+			FilePath:   filePath,
+			Info:       exercise.ExerciseInfo{Name: "iter", Category: "test", GoVersion: "1.23+"},
+			Validation: exercise.ExerciseValidation{Mode: "build"},
+		}
+		result, err := r.RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		if !result.Success {
+			t.Errorf("expected the exercise to build with its go_version, got: %s", result.Output)
+		}
+
+		data, _ := os.ReadFile(goMod)
+		if string(data) != "module goforgo\n\ngo 1.21\n" {
+			t.Errorf("expected the shared go.mod untouched, got:\n%s", data)
+		}
+		if _, err := os.Stat(filepath.Join(exerciseDir, "go.mod")); err == nil {
+			t.Error("expected no per-directory go.mod inside a workspace module")
+		}
+		if r.modFile != "" {
+			t.Errorf("expected the staged go.mod to be dropped after the run, got %s", r.modFile)
+		}
+	})
+}
+
+func TestParseTestEvents(t *testing.T) {
+	stream := `{"Action":"start","Package":"p"}
+{"Action":"run","Package":"p","Test":"TestAdd"}
+{"Action":"output","Package":"p","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"run","Package":"p","Test":"TestAdd/zero"}
+{"Action":"pass","Package":"p","Test":"TestAdd/zero","Elapsed":0}
+{"Action":"run","Package":"p","Test":"TestAdd/negative"}
+{"Action":"output","Package":"p","Test":"TestAdd/negative","Output":"    add_test.go:12: Add(-1, -2) = 1, want -3\n"}
+{"Action":"output","Package":"p","Test":"TestAdd/negative","Output":"--- FAIL: TestAdd/negative (0.00s)\n"}
+{"Action":"fail","Package":"p","Test":"TestAdd/negative","Elapsed":0.01}
+{"Action":"fail","Package":"p","Test":"TestAdd","Elapsed":0.01}
+{"Action":"run","Package":"p","Test":"TestLater"}
+{"Action":"skip","Package":"p","Test":"TestLater","Elapsed":0}
+{"Action":"output","Package":"p","Output":"FAIL\n"}
+{"Action":"fail","Package":"p","Elapsed":0.02}`
+
+	tests, extra := parseTestEvents(stream)
+	if extra != "" {
+		t.Errorf("expected package summary to be dropped, got extra %q", extra)
+	}
+	if len(tests) != 2 || len(tests[0].Subtests) != 2 {
+		t.Fatalf("expected TestAdd with 2 subtests and TestLater, got %+v", tests)
+	}
+	if tests[1].Status != TestSkip {
+		t.Errorf("expected TestLater skipped, got %s", tests[1].Status)
+	}
+
+	first := FirstFailure(tests)
+	if first == nil || first.Name != "TestAdd/negative" {
+		t.Fatalf("expected first failure TestAdd/negative, got %+v", first)
+	}
+	if strings.Contains(first.Output, "--- FAIL") || !strings.Contains(first.Output, "want -3") {
+		t.Errorf("expected only the assertion output, got %q", first.Output)
+	}
+
+	text := formatTestResults(tests, extra)
+	for _, want := range []string{"1/3 tests passed (1 skipped)", "✅ zero", "❌ negative", "want -3"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("checklist missing %q:\n%s", want, text)
+		}
+	}
+
+	// Build failures arrive as non-test output and are surfaced as-is
+	_, extra = parseTestEvents("# p\n./add.go:3:9: undefined: x\nFAIL\tp [build failed]")
+	if !strings.Contains(extra, "undefined: x") {
+		t.Errorf("expected build error in extra output, got %q", extra)
+	}
+}
+
+func TestParseTestEvents_Packages(t *testing.T) {
+	stream := `{"Action":"run","Package":"goforgo/layout","Test":"TestNew"}
+{"Action":"pass","Package":"goforgo/layout","Test":"TestNew","Elapsed":0.01}
+{"Action":"run","Package":"goforgo/layout/internal/store","Test":"TestNew"}
+{"Action":"output","Package":"goforgo/layout/internal/store","Test":"TestNew","Output":"    store_test.go:9: got nil store\n"}
+{"Action":"fail","Package":"goforgo/layout/internal/store","Test":"TestNew","Elapsed":0.02}`
+
+	tests, _ := parseTestEvents(stream)
+	if len(tests) != 2 {
+		t.Fatalf("expected a row per package, got %+v", tests)
+	}
+	layout, store := tests[0], tests[1]
+	if layout.ShortName() != "goforgo/layout" || layout.Status != TestPass || len(layout.Subtests) != 1 || layout.Subtests[0].Status != TestPass {
+		t.Errorf("expected goforgo/layout's TestNew to pass, got %+v", layout)
+	}
+	if store.Status != TestFail || len(store.Subtests) != 1 || store.Subtests[0].Status != TestFail {
+		t.Errorf("expected the store's TestNew to fail, got %+v", store)
+	}
+
+	text := formatTestResults(tests, "")
+	for _, want := range []string{"1/2 tests passed", "❌ goforgo/layout/internal/store", "got nil store"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("checklist missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestRunner_TestMode(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "add.go")
+	testPath := filepath.Join(tempDir, "add_test.go")
+
+	if err := os.WriteFile(filePath, []byte("package main\n\nfunc Add(a, b int) int { return a - b }\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testContent := `package main
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	for _, c := range []struct{ name string; a, b, want int }{{"zero", 0, 0, 0}, {"positive", 1, 2, 3}} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Add(c.a, c.b); got != c.want {
+				t.Errorf("Add(%d, %d) = %d, want %d", c.a, c.b, got, c.want)
+			}
+		})
+	}
+}
+`
+	if err := os.WriteFile(testPath, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ex := &exercise.Exercise{
+		FilePath:     filePath,
+		TestFilePath: testPath,
+		Info:         exercise.ExerciseInfo{Name: "add", Category: "test"},
+		Validation:   exercise.ExerciseValidation{Mode: "test", Timeout: "60s"},
+	}
+
+	result, err := NewRunner(tempDir).RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if result.Success {
+		t.Fatal("expected failing tests")
+	}
+
+	first := FirstFailure(result.Validation.Tests)
+	if first == nil || first.Name != "TestAdd/positive" {
+		t.Fatalf("expected TestAdd/positive to fail first, got %+v", result.Validation.Tests)
+	}
+	if !strings.Contains(result.Output, "1/2 tests passed") {
+		t.Errorf("expected checklist summary in output, got:\n%s", result.Output)
+	}
+}
+
+func TestBenchmarks(t *testing.T) {
+	output := `goos: linux
+BenchmarkPlus-8      	  400000	       300 ns/op	      88 B/op	       4 allocs/op
+BenchmarkPlus-8      	  400000	       330 ns/op	      88 B/op	       4 allocs/op
+BenchmarkPlus-8      	  400000	       270 ns/op	      88 B/op	       4 allocs/op
+BenchmarkJoin-8      	 1000000	       100 ns/op	      32 B/op	       1 allocs/op
+BenchmarkJoin-8      	 1000000	       110 ns/op	      32 B/op	       1 allocs/op
+BenchmarkJoin-8      	 1000000	        90 ns/op	      32 B/op	       1 allocs/op
+PASS`
+
+	stats := parseBenchOutput(output)
+	if len(stats) != 2 || stats[0].Name != "BenchmarkPlus" {
+		t.Fatalf("expected BenchmarkPlus and BenchmarkJoin, got %+v", stats)
+	}
+	if stats[0].NsPerOp != 300 || len(stats[0].Samples) != 3 {
+		t.Errorf("expected median 300ns over 3 samples, got %+v", stats[0])
+	}
+	if stats[1].Variance < 0.09 || stats[1].Variance > 0.11 {
+		t.Errorf("expected ~10%% variance, got %f", stats[1].Variance)
+	}
+	if !strings.Contains(formatBenchStats(stats), "300.00ns ± 10%") {
+		t.Errorf("unexpected summary:\n%s", formatBenchStats(stats))
+	}
+
+	oneAlloc, zeroAllocs := 1, 0
+	passed, lines := checkBenchThresholds(stats, []exercise.BenchThreshold{
+		{Benchmark: "BenchmarkJoin", FasterThan: "BenchmarkPlus", MinSpeedup: 2.5, MaxAllocsPerOp: &oneAlloc},
+		{Benchmark: "BenchmarkJoin", MaxNsPerOp: 150},
+	})
+	if !passed {
+		t.Errorf("expected thresholds to pass, got %v", lines)
+	}
+
+	passed, lines = checkBenchThresholds(stats, []exercise.BenchThreshold{
+		{Benchmark: "BenchmarkJoin", MaxAllocsPerOp: &zeroAllocs},
+		{Benchmark: "BenchmarkMissing", MaxNsPerOp: 1},
+		{Benchmark: "BenchmarkPlus", FasterThan: "BenchmarkJoin"}, // Slower than its baseline
+	})
+	if passed || len(lines) != 3 {
+		t.Errorf("expected all thresholds to fail, got %v", lines)
+	}
+}
+
+func TestFuzzTarget(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	good := write("good_test.go", `package main
+import "testing"
+func FuzzReverse(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) { _ = Reverse(s) })
+}`)
+	empty := write("empty_test.go", `package main
+import "testing"
+func FuzzReverse(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {})
+}`)
+	noFuzz := write("nofuzz_test.go", `package main
+import "testing"
+func FuzzReverse(f *testing.F) { f.Add("x") }`)
+
+	if err := checkFuzzTarget([]string{good}, "FuzzReverse", "Reverse"); err != nil {
+		t.Errorf("expected target to pass, got %v", err)
+	}
+	if err := checkFuzzTarget([]string{good}, "FuzzMissing", ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected missing target error, got %v", err)
+	}
+	if err := checkFuzzTarget([]string{empty}, "FuzzReverse", "Reverse"); err == nil || !strings.Contains(err.Error(), "never calls Reverse") {
+		t.Errorf("expected empty fuzz function to fail, got %v", err)
+	}
+	if err := checkFuzzTarget([]string{noFuzz}, "FuzzReverse", ""); err == nil || !strings.Contains(err.Error(), "f.Fuzz") {
+		t.Errorf("expected missing f.Fuzz to fail, got %v", err)
+	}
+
+	corpus := filepath.Join("testdata", "fuzz", "FuzzReverse", "abc123")
+	if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(corpus)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write(corpus, "go test fuzz v1\nstring(\"\\x80\")\n")
+
+	crashers := fuzzCrashers(dir, "--- FAIL: FuzzReverse (0.02s)\n    Failing input written to "+corpus+"\n")
+	if len(crashers) != 1 || crashers[0].Path != corpus || !strings.Contains(crashers[0].Input, `string("\x80")`) {
+		t.Errorf("unexpected crashers: %+v", crashers)
+	}
+}
+
+func TestRunner_Coverage(t *testing.T) {
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "calc.go")
+	testPath := filepath.Join(tempDir, "calc_test.go")
+
+	source := `package main
+
+type Stack struct{ items []int }
+
+func (s *Stack) Push(v int) { s.items = append(s.items, v) }
+
+func Add(a, b int) int { return a + b }
+
+func Sub(a, b int) int {
+	if a < b {
+		return -(b - a)
+	}
+	return a - b
+}
+
+func main() {}
+`
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(testPath, []byte("package main\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fail()\n\t}\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ex := &exercise.Exercise{
+		FilePath:     filePath,
+		TestFilePath: testPath,
+		Info:         exercise.ExerciseInfo{Name: "calc", Category: "test"},
+		Validation: exercise.ExerciseValidation{
+			Mode:        "test",
+			Timeout:     "60s",
+			MinCoverage: 50,
+			MustCover:   []string{"Add", "Stack.Push"},
+		},
+	}
+
+	result, err := NewRunner(tempDir).RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if result.Success || !result.Validation.TestSuccess || result.Validation.CoverageSuccess {
+		t.Fatalf("expected passing tests with failing coverage, got %+v\n%s", result.Validation, result.Output)
+	}
+
+	byName := make(map[string]FuncCoverage)
+	for _, fc := range result.Validation.FuncCoverage {
+		byName[fc.Name] = fc
+	}
+	if fc := byName["Add"]; fc.Total != 1 || fc.Covered != 1 {
+		t.Errorf("expected Add fully covered, got %+v", fc)
+	}
+	if fc := byName["Sub"]; fc.Total != 3 || fc.Covered != 0 {
+		t.Errorf("expected Sub uncovered with 3 statements, got %+v", fc)
+	}
+	if !strings.Contains(result.Output, "Stack.Push: never executed") {
+		t.Errorf("expected must_cover failure for Stack.Push, got:\n%s", result.Output)
+	}
+
+	ex.Validation.MinCoverage = 10
+	ex.Validation.MustCover = []string{"Add"}
+	result, err = NewRunner(tempDir).RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("expected coverage targets to pass, got:\n%s", result.Output)
+	}
+}
+
+func TestRunner_HiddenTests(t *testing.T) {
+	tempDir := t.TempDir()
+	exerciseDir := filepath.Join(tempDir, "exercises", "test")
+	if err := os.MkdirAll(exerciseDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(exerciseDir, "add.go")
+	testPath := filepath.Join(exerciseDir, "add_test.go")
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filePath, "package main\n\nfunc Add(a, b int) int { return a - b }\n\nfunc main() {}\n")
+	// A learner "fixed" the visible test instead of the code
+	write(testPath, "package main\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n")
+
+	hidden := `package main
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if got := Add(2, 3); got != 5 {
+		t.Errorf("secret: Add(2, 3) = %d", got)
+	}
+}
+
+func TestAddZero(t *testing.T) {
+	if got := Add(0, 0); got != 0 {
+		t.Errorf("Add(0, 0) = %d", got)
+	}
+}
+`
+	ex := &exercise.Exercise{
+		FilePath:     filePath,
+		TestFilePath: testPath,
+		Files:        []string{filePath, testPath},
+		Info:         exercise.ExerciseInfo{Name: "add", Category: "test"},
+		Validation:   exercise.ExerciseValidation{Mode: "test", Timeout: "60s", TodoPolicy: "none"},
+		HiddenTests: []exercise.HiddenTest{{
+			Name:     "add_hidden_test.go",
+			Embedded: "solutions/test/add_hidden_test.go",
+			Path:     filepath.Join(tempDir, "solutions", "test", "add_hidden_test.go"),
+		}},
+	}
+
+	r := NewRunner(tempDir)
+	r.Content = fstest.MapFS{"solutions/test/add_hidden_test.go": {Data: []byte(hidden)}}
+
+	for _, tc := range []struct {
+		detail      string
+		wantTests   bool
+		wantOutput  string
+		hideMessage bool
+	}{
+		{detail: HiddenDetailSummary, wantTests: false, wantOutput: "1 of 2 hidden tests failed", hideMessage: true},
+		{detail: HiddenDetailNames, wantTests: true, wantOutput: "❌ TestAdd", hideMessage: true},
+		{detail: HiddenDetailFull, wantTests: true, wantOutput: "secret: Add(2, 3) = -1"},
+	} {
+		t.Run(tc.detail, func(t *testing.T) {
+			ex.Validation.HiddenDetail = tc.detail
+			result, err := r.RunExercise(ex)
+			if err != nil {
+				t.Fatalf("RunExercise returned error: %v", err)
+			}
+			if result.Success {
+				t.Fatal("expected hidden tests to fail despite the edited visible test")
+			}
+			if (len(result.Validation.Tests) > 0) != tc.wantTests {
+				t.Errorf("expected tests reported = %v, got %+v", tc.wantTests, result.Validation.Tests)
+			}
+			if !strings.Contains(result.Output, tc.wantOutput) {
+				t.Errorf("expected %q in output, got:\n%s", tc.wantOutput, result.Output)
+			}
+			if tc.hideMessage && strings.Contains(result.Output, "secret") {
+				t.Errorf("hidden test output leaked at detail %q:\n%s", tc.detail, result.Output)
+			}
+		})
+	}
+
+	write(filePath, "package main\n\nfunc Add(a, b int) int { return a + b }\n\nfunc main() {}\n")
+	result, err := r.RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if !result.Success || !strings.Contains(result.Output, "All 2 hidden tests passed") {
+		t.Errorf("expected hidden tests to pass, got:\n%s", result.Output)
+	}
+
+	if entries, _ := filepath.Glob(filepath.Join(tempDir, ".goforgo", "hidden-*")); len(entries) > 0 {
+		t.Errorf("expected build directories to be removed, found %v", entries)
+	}
+}
+
+func TestFindMutations(t *testing.T) {
+	src := `package main
+
+func Clamp(x, limit int) int {
+	if x > limit {
+		return limit
+	}
+	return x + 0
+}
+
+func main() { _ = Clamp(1, 2) > 0 }
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "clamp.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []string
+	for _, m := range findMutations(fset, file, allMutationOperators) {
+		if m.Function != "Clamp" {
+			t.Errorf("main should not be mutated, got %+v", m.Mutant)
+		}
+		changes = append(changes, m.Change)
+	}
+	want := []string{"x > limit → x >= limit", "removed `return limit`", "x + 0 → x - 0", "0 → 1"}
+	if strings.Join(changes, "|") != strings.Join(want, "|") {
+		t.Errorf("expected mutations %q, got %q", want, changes)
+	}
+
+	comparisons := findMutations(fset, file, []string{MutateComparison})
+	if len(comparisons) != 1 || comparisons[0].Line != 4 {
+		t.Errorf("expected only the comparison on line 4, got %+v", comparisons)
+	}
+
+	passed, report := mutationReport([]Mutant{
+		{Function: "Clamp", Line: 4, Operator: MutateComparison, Change: "x > limit → x >= limit"},
+		{Function: "Clamp", Line: 5, Operator: MutateReturn, Killed: true},
+	}, 1, 0.8)
+	if passed || !strings.Contains(report, "1/2 mutants killed") || !strings.Contains(report, "x > limit → x >= limit") {
+		t.Errorf("unexpected report (passed=%v):\n%s", passed, report)
+	}
+}
+
+func TestRunner_MutationMode(t *testing.T) {
+	tempDir := t.TempDir()
+	implPath := filepath.Join(tempDir, "adult.go")
+	testPath := filepath.Join(tempDir, "adult_test.go")
+
+	if err := os.WriteFile(implPath, []byte("package main\n\nfunc IsAdult(age int) bool {\n\treturn age >= 18\n}\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ex := &exercise.Exercise{
+		FilePath:   testPath,
+		Files:      []string{testPath},
+		Info:       exercise.ExerciseInfo{Name: "adult_test", Category: "test"},
+		Validation: exercise.ExerciseValidation{Mode: "mutation", Timeout: "60s", Mutation: exercise.MutationConfig{Target: "adult.go", Threshold: 1}},
+	}
+
+	r := NewRunner(tempDir)
+	run := func(tests string) *Result {
+		t.Helper()
+		if err := os.WriteFile(testPath, []byte("package main\n\nimport \"testing\"\n\nfunc TestIsAdult(t *testing.T) {\n"+tests+"}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		result, err := r.RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		return result
+	}
+
+	weak := run("\tif !IsAdult(30) {\n\t\tt.Fail()\n\t}\n")
+	if weak.Success || len(weak.Validation.Mutants) == 0 || !strings.Contains(weak.Output, "Surviving mutants") {
+		t.Fatalf("expected surviving mutants with a positive-only test, got:\n%s%s", weak.Output, weak.Error)
+	}
+
+	strongTests := "\tfor age, want := range map[int]bool{17: false, 18: true, 30: true} {\n\t\tif IsAdult(age) != want {\n\t\t\tt.Errorf(\"IsAdult(%d) = %v\", age, !want)\n\t\t}\n\t}\n"
+	strong := run(strongTests)
+	if !strong.Success || strong.Validation.MutationScore != 1 {
+		t.Errorf("expected every mutant killed, got:\n%s%s", strong.Output, strong.Error)
+	}
+
+	// A machine-wide sandbox is skipped with a note; one the exercise asks for is an error
+	r.Sandbox = SandboxStrict
+	if skipped := run(strongTests); !skipped.Success || len(skipped.Validation.SandboxNotes) == 0 {
+		t.Errorf("expected mutation mode to skip the default sandbox, got:\n%s%s", skipped.Output, skipped.Error)
+	}
+	ex.Validation.Sandbox = SandboxStrict
+	if refused := run(strongTests); refused.Success || !strings.Contains(refused.Error, "sandbox") {
+		t.Errorf("expected an exercise asking for the sandbox to fail, got:\n%s%s", refused.Output, refused.Error)
+	}
+}
+
+func TestRunner_StaticChecks(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "main.go")
+	src := "package main\n\nimport (\n\t\"errors\"\n\t\"fmt\"\n\t\"io\"\n)\n\nfunc main() {\n\tfmt.Println(errors.Is(io.EOF, io.EOF))\n}\n"
+	if err := os.WriteFile(mainPath, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(v exercise.ExerciseValidation) *Result {
+		t.Helper()
+		v.Timeout = "60s"
+		ex := &exercise.Exercise{FilePath: mainPath, Info: exercise.ExerciseInfo{Name: "static", Category: "test"}, Validation: v}
+		result, err := NewRunner(tempDir).RunExercise(ex)
+		if err != nil {
+			t.Fatalf("RunExercise returned error: %v", err)
+		}
+		return result
+	}
+	mustCall := exercise.StaticCheckSpec{Name: "must_call", Args: map[string]string{"func": "errors.Is"}}
+	noFmt := exercise.StaticCheckSpec{Name: "forbid_import", Args: map[string]string{"path": "fmt"}}
+
+	all := run(exercise.ExerciseValidation{Mode: "run", ExpectedOutput: "true", StaticChecks: []exercise.StaticCheckSpec{mustCall, noFmt}})
+	if all.Success || !all.Validation.StaticResults[0].Success || all.Validation.StaticResults[1].Success {
+		t.Fatalf("expected forbid_import to fail the all-of checks, got:\n%s%s", all.Output, all.Error)
+	}
+	if !strings.Contains(all.Output, "🔍 Static checks:") || !strings.Contains(all.Output, `main.go:5:2: package "fmt"`) {
+		t.Errorf("expected the static report after the run output, got:\n%s", all.Output)
+	}
+
+	anyOf := run(exercise.ExerciseValidation{Mode: "run", ExpectedOutput: "true", StaticMatch: "any", StaticChecks: []exercise.StaticCheckSpec{mustCall, noFmt}})
+	if !anyOf.Success {
+		t.Errorf("expected any-of checks to pass, got:\n%s%s", anyOf.Output, anyOf.Error)
+	}
+
+	wrongOutput := run(exercise.ExerciseValidation{Mode: "run", ExpectedOutput: "false", StaticChecks: []exercise.StaticCheckSpec{mustCall}})
+	if wrongOutput.Success || len(wrongOutput.Validation.StaticResults) != 0 {
+		t.Errorf("expected static checks to be skipped when the output is wrong, got %+v", wrongOutput.Validation.StaticResults)
+	}
+
+	static := run(exercise.ExerciseValidation{Mode: "static", StaticCheck: "forbid_import", StaticArgs: map[string]string{"path": "os"}})
+	if !static.Success {
+		t.Errorf("expected the single static_check to pass, got:\n%s%s", static.Output, static.Error)
+	}
+
+	unknown := run(exercise.ExerciseValidation{Mode: "build", StaticChecks: []exercise.StaticCheckSpec{{Name: "no_such_check"}}})
+	if unknown.Success || !strings.Contains(unknown.Output, "Unknown static check: no_such_check") {
+		t.Errorf("expected an unknown check to fail, got:\n%s%s", unknown.Output, unknown.Error)
+	}
+}
+
+func TestRunner_QualityGate(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "main.go")
+	src := "package main\n\nimport \"fmt\"\n\nfunc find(m map[string]*int, k string) int {\n\tp := m[k]\n\tif p == nil {\n\t\treturn *p\n\t}\n\treturn 0\n}\n\nfunc main(){\n\tfmt.Printf(\"%d\\n\", \"one\")\n\t_ = find(nil, \"\")\n}\n"
+	if err := os.WriteFile(mainPath, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ex := &exercise.Exercise{
+		FilePath: mainPath,
+		Info:     exercise.ExerciseInfo{Name: "quality", Category: "test"},
+		Validation: exercise.ExerciseValidation{
+			Mode:    "build",
+			Timeout: "120s",
+			Quality: exercise.QualityConfig{Gofmt: "block", Vet: "warn", Analyzers: "warn"},
+		},
+	}
+
+	result, err := NewRunner(tempDir).RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if result.Success || result.Validation.QualitySuccess {
+		t.Fatalf("expected unformatted code to be blocked, got:\n%s%s", result.Output, result.Error)
+	}
+	tools := make(map[string]bool)
+	for _, f := range result.Validation.QualityFindings {
+		tools[f.Tool] = true
+	}
+	if !tools["gofmt"] || !tools["vet"] || !tools["analyzers"] {
+		t.Errorf("expected gofmt, vet and analyzer findings, got %+v", result.Validation.QualityFindings)
+	}
+	if !strings.Contains(result.Output, "+++ main.go (gofmt)") || !strings.Contains(result.Output, "+func main() {") {
+		t.Errorf("expected the gofmt diff in the output, got:\n%s", result.Output)
+	}
+
+	changed, err := FormatFile(mainPath)
+	if err != nil || !changed {
+		t.Fatalf("FormatFile() = %v, %v", changed, err)
+	}
+	result, err = NewRunner(tempDir).RunExercise(ex)
+	if err != nil {
+		t.Fatalf("RunExercise returned error: %v", err)
+	}
+	if !result.Success || !result.Validation.QualitySuccess || !strings.Contains(result.Output, "⚠️  vet: main.go:14:14: printf:") {
+		t.Errorf("expected warnings to leave the exercise passing, got:\n%s%s", result.Output, result.Error)
+	}
+}
+
+func TestFailureKind(t *testing.T) {
+	run := &exercise.Exercise{Validation: exercise.ExerciseValidation{Mode: "run"}}
+	test := &exercise.Exercise{Validation: exercise.ExerciseValidation{Mode: "test"}}
+
+	tests := []struct {
+		name   string
+		ex     *exercise.Exercise
+		result Result
+		want   string
+	}{
+		{"success", run, Result{Success: true}, ""},
+		{"runner error", run, Result{Error: "timed out"}, exercise.FailureError},
+		{"missing files", run, Result{}, exercise.FailureSetup},
+		{"compile error", run, Result{Validation: ValidationResult{BuildOutput: "undefined: x"}}, exercise.FailureBuild},
+		{"wrong output", run, Result{Validation: ValidationResult{BuildSuccess: true}}, exercise.FailureOutput},
+		{"failing tests", test, Result{Validation: ValidationResult{BuildSuccess: true, TestOutput: "FAIL"}}, exercise.FailureTest},
+		{"static check", test, Result{Validation: ValidationResult{BuildSuccess: true, TestSuccess: true, StaticOutput: "❌"}}, exercise.FailureStatic},
+		{"quality gate", run, Result{Validation: ValidationResult{BuildSuccess: true, QualityFindings: []QualityFinding{{Blocking: true}}}}, exercise.FailureQuality},
+		{"todos", run, Result{Validation: ValidationResult{BuildSuccess: true, TodoOutput: "TODO"}}, exercise.FailureTodo},
+	}
+	for _, tt := range tests {
+		if got := FailureKind(tt.ex, &tt.result); got != tt.want {
+			t.Errorf("%s: FailureKind = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
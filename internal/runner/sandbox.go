@@ -0,0 +1,227 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// Sandbox modes, selected via sandbox in the exercise TOML or Runner.Sandbox.
+const (
+	SandboxNone   = "none"
+	SandboxBasic  = "basic"
+	SandboxStrict = "strict"
+)
+
+// sandboxLimits describes the resource caps applied to a sandboxed process
+type sandboxLimits struct {
+	CPUSeconds     uint64 // RLIMIT_CPU
+	MemoryBytes    uint64 // RLIMIT_DATA (heap and writable mappings)
+	OpenFiles      uint64 // RLIMIT_NOFILE
+	MaxFileSize    uint64 // RLIMIT_FSIZE
+	Processes      uint64 // RLIMIT_NPROC, on top of the processes the user already runs
+	IsolateNetwork bool   // Run in a private network namespace when the kernel allows it
+}
+
+var sandboxProfiles = map[string]sandboxLimits{
+	SandboxBasic: {
+		CPUSeconds:  30,
+		MemoryBytes: 1 << 30,
+		OpenFiles:   256,
+		MaxFileSize: 64 << 20,
+		Processes:   256,
+	},
+	SandboxStrict: {
+		CPUSeconds:     10,
+		MemoryBytes:    256 << 20,
+		OpenFiles:      64,
+		MaxFileSize:    16 << 20,
+		Processes:      64,
+		IsolateNetwork: true,
+	},
+}
+
+// errSandboxUnsupported is returned on platforms without sandbox support
+var errSandboxUnsupported = errors.New("sandboxed execution is only supported on Linux")
+
+// sandbox is a private, compiled copy of an exercise that runs under resource limits
+type sandbox struct {
+	mode    string
+	limits  sandboxLimits
+	root    string // Private temp directory, removed by Close
+	workDir string // Copy of the exercise directory the program runs in
+	binary  string // Compiled learner program
+	timeout time.Duration
+	notes   []string // Degradations worth telling the learner about, e.g. no network isolation
+}
+
+// sandboxMode resolves the sandbox mode for an exercise, falling back to the runner default
+func (r *Runner) sandboxMode(ex *exercise.Exercise) string {
+	if ex.Validation.Sandbox != "" {
+		return ex.Validation.Sandbox
+	}
+	if r.Sandbox != "" {
+		return r.Sandbox
+	}
+	return SandboxNone
+}
+
+// newSandbox compiles the exercise with the given go subcommand ("build" or "test -c")
+// and stages a private copy of its directory for the program to run in.
+func (r *Runner) newSandbox(exerciseDir, mode string, buildArgs ...string) (*sandbox, error) {
+	limits, ok := sandboxProfiles[mode]
+	if !ok {
+		return nil, fmt.Errorf("unknown sandbox mode: %s", mode)
+	}
+	if !sandboxSupported {
+		return nil, errSandboxUnsupported
+	}
+
+	root, err := os.MkdirTemp("", "goforgo-sandbox-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox directory: %w", err)
+	}
+
+	s := &sandbox{
+		mode:    mode,
+		limits:  limits,
+		root:    root,
+		workDir: filepath.Join(root, "work"),
+		binary:  filepath.Join(root, "bin", "exercise"),
+		timeout: r.timeout,
+	}
+
+	if err := copyDir(exerciseDir, s.workDir); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to copy exercise into sandbox: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0o700); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to create sandbox temp directory: %w", err)
+	}
+
+	// Compile outside the sandbox; only the learner's program runs under limits.
+	args := append([]string{"-o", s.binary}, buildArgs[1:]...)
+	success, output, err := r.runGoCommand(exerciseDir, buildArgs[0], args...)
+	if err != nil || !success {
+		_ = s.Close()
+		if err == nil {
+			err = errors.New(output)
+		}
+		return nil, fmt.Errorf("failed to compile exercise for sandbox: %w", err)
+	}
+
+	return s, nil
+}
+
+// run executes the sandboxed program and reports why it was killed, if it was
+func (s *sandbox) run(args []string, input commandInput) (success bool, output string, killReason string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var stdin io.Reader
+	if input.Stdin != "" {
+		stdin = strings.NewReader(input.Stdin)
+	}
+	stdout := newCappedOutputBuffer(maxCommandOutputBytes)
+	stderr := newCappedOutputBuffer(maxCommandOutputBytes)
+
+	state, err := runSandboxedProcess(ctx, s, args, stdin, stdout, stderr, s.environment(input.Env))
+	output = strings.TrimSpace(stdout.String() + stderr.String())
+
+	if ctx.Err() != nil {
+		return false, output, fmt.Sprintf("wall-clock timeout of %v exceeded", s.timeout), nil
+	}
+	if err != nil {
+		return false, output, "", err
+	}
+	if state.Success() {
+		return true, output, "", nil
+	}
+
+	if reason := signalKillReason(state, s.limits); reason != "" {
+		return false, output, reason, nil
+	}
+	return false, output, outputKillReason(output, s.limits), nil
+}
+
+// environment builds a scrubbed environment so learner code can't read the user's secrets
+func (s *sandbox) environment(extra map[string]string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + s.workDir,
+		"TMPDIR=" + filepath.Join(s.root, "tmp"),
+		"LANG=C.UTF-8",
+	}
+
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+extra[key])
+	}
+
+	return env
+}
+
+// Close removes the sandbox directory
+func (s *sandbox) Close() error {
+	return os.RemoveAll(s.root)
+}
+
+// outputKillReason infers which limit a process hit from the Go runtime's error output
+func outputKillReason(output string, limits sandboxLimits) string {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "out of memory"), strings.Contains(lower, "cannot allocate memory"):
+		return fmt.Sprintf("memory limit of %dMB exceeded", limits.MemoryBytes>>20)
+	case strings.Contains(lower, "too many open files"):
+		return fmt.Sprintf("open file limit of %d exceeded", limits.OpenFiles)
+	case strings.Contains(lower, "file too large"):
+		return fmt.Sprintf("file size limit of %dMB exceeded", limits.MaxFileSize>>20)
+	case strings.Contains(lower, "resource temporarily unavailable") &&
+		(strings.Contains(lower, "pthread_create") || strings.Contains(lower, "fork")):
+		return fmt.Sprintf("process/thread limit of %d exceeded", limits.Processes)
+	default:
+		return ""
+	}
+}
+
+// copyDir copies the regular files under src into dst, preserving the directory layout
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil // Skip symlinks and devices so the copy can't point back outside
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,240 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const sandboxSupported = true
+
+// sandboxGateScript blocks on fd 3 until the parent has applied rlimits, then execs the program.
+// Limits set on the shell survive the exec, so the learner code never runs unconstrained.
+const sandboxGateScript = `read -r _ <&3; exec 3<&-; exec "$@"`
+
+// runSandboxedProcess starts the sandboxed program, applies resource limits and waits for it
+func runSandboxedProcess(ctx context.Context, s *sandbox, args []string, stdin io.Reader, stdout, stderr io.Writer, env []string) (*os.ProcessState, error) {
+	isolate := s.limits.IsolateNetwork
+	state, err := startGatedProcess(ctx, s, isolate, args, stdin, stdout, stderr, env)
+	if err != nil && isolate && isNamespaceUnavailable(err) {
+		// Unprivileged user namespaces are disabled on some distributions; degrade rather than fail.
+		s.notes = appendOnce(s.notes, "network isolation unavailable on this system")
+		state, err = startGatedProcess(ctx, s, false, args, stdin, stdout, stderr, env)
+	}
+	return state, err
+}
+
+func startGatedProcess(ctx context.Context, s *sandbox, isolate bool, args []string, stdin io.Reader, stdout, stderr io.Writer, env []string) (*os.ProcessState, error) {
+	gateR, gateW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox gate: %w", err)
+	}
+	defer gateW.Close()
+
+	shArgs := append([]string{"-c", sandboxGateScript, "goforgo-sandbox", s.binary}, args...)
+	cmd := exec.CommandContext(ctx, "/bin/sh", shArgs...)
+	cmd.Dir = s.workDir
+	cmd.Env = env
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.ExtraFiles = []*os.File{gateR}
+
+	attr := &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+	}
+	if isolate {
+		attr.Cloneflags = syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	}
+	cmd.SysProcAttr = attr
+
+	// Kill the whole process group so spawned children don't outlive the timeout.
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	processes, err := s.processLimit(isolate)
+	if err != nil {
+		_ = gateR.Close()
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		_ = gateR.Close()
+		return nil, err
+	}
+	_ = gateR.Close()
+
+	if err := applyRlimits(cmd.Process.Pid, s.limits, processes); err != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("failed to apply sandbox limits: %w", err)
+	}
+
+	if _, err := gateW.Write([]byte("\n")); err != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("failed to release sandboxed process: %w", err)
+	}
+	_ = gateW.Close()
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return cmd.ProcessState, err
+	}
+	return cmd.ProcessState, nil
+}
+
+// processLimit returns the RLIMIT_NPROC value that lets the sandboxed program start
+// limits.Processes processes and threads, or 0 for none. The limit counts everything
+// the user runs, so outside a private user namespace, where the count starts from
+// zero, the user's current count is added. Strict mode refuses to run without it.
+func (s *sandbox) processLimit(isolated bool) (uint64, error) {
+	if s.limits.Processes == 0 {
+		return 0, nil
+	}
+	if os.Geteuid() == 0 {
+		// Root is exempt from RLIMIT_NPROC
+		s.notes = appendOnce(s.notes, "process limit not enforced when running as root")
+		return 0, nil
+	}
+	if isolated {
+		return s.limits.Processes, nil
+	}
+
+	running, err := userTaskCount(os.Getuid())
+	if err != nil {
+		if s.mode == SandboxStrict {
+			return 0, fmt.Errorf("can't limit the sandboxed program's processes: %w", err)
+		}
+		s.notes = appendOnce(s.notes, fmt.Sprintf("process limit not applied: %v", err))
+		return 0, nil
+	}
+	return uint64(running) + s.limits.Processes, nil
+}
+
+// userTaskCount returns the number of processes and threads whose real user is uid,
+// which is what RLIMIT_NPROC is checked against
+func userTaskCount(uid int) (int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "status"))
+		if err != nil {
+			continue // The process exited, or isn't ours to read
+		}
+		var realUID, threads int
+		for _, line := range strings.Split(string(data), "\n") {
+			field, value, _ := strings.Cut(line, ":")
+			fields := strings.Fields(value)
+			if len(fields) == 0 {
+				continue
+			}
+			switch field {
+			case "Uid":
+				realUID, _ = strconv.Atoi(fields[0])
+			case "Threads":
+				threads, _ = strconv.Atoi(fields[0])
+			}
+		}
+		if realUID == uid {
+			count += threads
+		}
+	}
+	if count == 0 {
+		return 0, errors.New("no processes of the user are visible in /proc")
+	}
+	return count, nil
+}
+
+// applyRlimits sets the sandbox resource limits on a running process, with processes
+// as its RLIMIT_NPROC
+func applyRlimits(pid int, limits sandboxLimits, processes uint64) error {
+	set := func(resource int, value uint64, name string) error {
+		if value == 0 {
+			return nil
+		}
+		// The one-unit gap between soft and hard lets the kernel send a catchable signal first.
+		rlimit := unix.Rlimit{Cur: value, Max: value}
+		if resource == unix.RLIMIT_CPU {
+			rlimit.Max = value + 1
+		}
+		if err := unix.Prlimit(pid, resource, &rlimit, nil); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := set(unix.RLIMIT_CPU, limits.CPUSeconds, "cpu"); err != nil {
+		return err
+	}
+	if err := set(unix.RLIMIT_DATA, limits.MemoryBytes, "memory"); err != nil {
+		return err
+	}
+	if err := set(unix.RLIMIT_NOFILE, limits.OpenFiles, "open files"); err != nil {
+		return err
+	}
+	if err := set(unix.RLIMIT_FSIZE, limits.MaxFileSize, "file size"); err != nil {
+		return err
+	}
+
+	if err := set(unix.RLIMIT_NPROC, processes, "processes"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// signalKillReason explains a signal-terminated process in terms of the sandbox limits
+func signalKillReason(state *os.ProcessState, limits sandboxLimits) string {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return ""
+	}
+
+	switch status.Signal() {
+	case syscall.SIGXCPU:
+		return fmt.Sprintf("CPU time limit of %ds exceeded", limits.CPUSeconds)
+	case syscall.SIGXFSZ:
+		return fmt.Sprintf("file size limit of %dMB exceeded", limits.MaxFileSize>>20)
+	case syscall.SIGKILL:
+		// Also sent by the OOM killer or anything else; guessing would mislead
+		return "killed (SIGKILL)"
+	default:
+		return fmt.Sprintf("terminated by signal %v", status.Signal())
+	}
+}
+
+// isNamespaceUnavailable reports whether starting a process failed because namespaces are not permitted
+func isNamespaceUnavailable(err error) bool {
+	return errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) ||
+		errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EACCES)
+}
+
+func appendOnce(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
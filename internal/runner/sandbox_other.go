@@ -0,0 +1,19 @@
+//go:build !linux
+
+package runner
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+const sandboxSupported = false
+
+func runSandboxedProcess(ctx context.Context, s *sandbox, args []string, stdin io.Reader, stdout, stderr io.Writer, env []string) (*os.ProcessState, error) {
+	return nil, errSandboxUnsupported
+}
+
+func signalKillReason(state *os.ProcessState, limits sandboxLimits) string {
+	return ""
+}
@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stonecharioteer/goforgo/internal/analysis"
+	"github.com/stonecharioteer/goforgo/internal/deps"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// How many of an exercise's static checks must pass
+const (
+	StaticMatchAll = "all" // Every check (default)
+	StaticMatchAny = "any" // At least one check
+)
+
+// StaticCheckResult is the outcome of one static check
+type StaticCheckResult struct {
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Output  string `json:"output"`
+}
+
+// runStaticChecks runs every static check and external analyzer the exercise declares and
+// combines them with its static_match. The exercise is type-checked at most once, however
+// many checks need it.
+func (r *Runner) runStaticChecks(exerciseDir string, ex *exercise.Exercise) (bool, string, []StaticCheckResult, error) {
+	specs := ex.Validation.StaticCheckList()
+	match := ex.Validation.StaticMatch
+	switch match {
+	case "":
+		match = StaticMatchAll
+	case StaticMatchAll, StaticMatchAny:
+	default:
+		return false, "", nil, fmt.Errorf("unknown static_match %q (want %q or %q)", match, StaticMatchAll, StaticMatchAny)
+	}
+
+	var pkg *analysis.Package
+	load := func() (*analysis.Package, error) {
+		if pkg != nil {
+			return pkg, nil
+		}
+		targets := buildTargets(ex)
+		if len(targets) == 0 {
+			targets = testTargets(ex)
+		}
+		var err error
+		pkg, err = analysis.Load(exerciseDir, deps.GoEnv(r.workingDir), targets...)
+		return pkg, err
+	}
+
+	results := make([]StaticCheckResult, 0, len(specs))
+	for _, spec := range specs {
+		check, exists := analysis.GetCheck(spec.Name)
+		if !exists {
+			results = append(results, StaticCheckResult{Name: spec.Name, Output: fmt.Sprintf("❌ Unknown static check: %s", spec.Name)})
+			continue
+		}
+
+		var success bool
+		var output string
+		var err error
+		if typed, ok := check.(analysis.TypedCheck); ok {
+			var loaded *analysis.Package
+			if loaded, err = load(); err == nil {
+				success, output, err = typed.Check(loaded, spec.Args)
+			}
+		} else if len(spec.Args) > 0 {
+			err = fmt.Errorf("takes no arguments")
+		} else {
+			success, output, err = check.Execute(ex.FilePath)
+		}
+		if err != nil {
+			return false, "", results, fmt.Errorf("%s: %w", spec.Name, err)
+		}
+		results = append(results, StaticCheckResult{Name: spec.Name, Success: success, Output: output})
+	}
+
+	for _, a := range ex.Validation.Analyzers {
+		res, err := r.runAnalyzer(exerciseDir, ex, a)
+		if err != nil {
+			return false, "", results, err
+		}
+		results = append(results, res)
+	}
+
+	passed := 0
+	for _, res := range results {
+		if res.Success {
+			passed++
+		}
+	}
+	success := passed == len(results)
+	if match == StaticMatchAny {
+		success = passed > 0
+	}
+	return success, staticReport(results, match, success), results, nil
+}
+
+// staticReport renders the output of each check. A single check's output is shown as is.
+func staticReport(results []StaticCheckResult, match string, success bool) string {
+	if len(results) == 1 {
+		return results[0].Output
+	}
+
+	var b strings.Builder
+	switch {
+	case match == StaticMatchAny && !success:
+		b.WriteString("❌ None of these checks passed; fix any one of them:\n")
+	case match == StaticMatchAny:
+		b.WriteString("✅ At least one of these checks passed:\n")
+	}
+	for _, res := range results {
+		icon := "✅"
+		if !res.Success {
+			icon = "❌"
+		}
+		fmt.Fprintf(&b, "%s %s\n", icon, res.Name)
+		for _, line := range strings.Split(res.Output, "\n") {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// applyStaticChecks runs the exercise's static checks after its main validation passed,
+// for modes other than "static", and appends their report.
+func (r *Runner) applyStaticChecks(exerciseDir string, ex *exercise.Exercise, result *Result) {
+	success, output, results, err := r.runStaticChecks(exerciseDir, ex)
+	if err != nil {
+		result.Success = false
+		result.Error = fmt.Sprintf("Static check failed: %v", err)
+		return
+	}
+	result.Validation.StaticSuccess = success
+	result.Validation.StaticOutput = output
+	result.Validation.StaticResults = results
+	result.Success = success
+	if !success {
+		result.Output = strings.TrimPrefix(result.Output+"\n\n🔍 Static checks:\n"+output, "\n\n")
+	}
+}
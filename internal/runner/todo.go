@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TODO policies, selected via todo_policy in the exercise TOML.
+const (
+	TodoPolicyAll      = "all"      // Every TODO comment blocks completion (default)
+	TodoPolicyRequired = "required" // Only TODO(required) blocks; plain TODOs are informational
+	TodoPolicyNone     = "none"     // TODO comments never block completion
+)
+
+// todoMarker matches a TODO word in a comment, optionally tagged as required.
+// Word boundaries keep identifiers such as todoList from matching.
+var todoMarker = regexp.MustCompile(`(?i)\bTODO\b(\(required\))?`)
+
+// TodoItem is a TODO marker found in an exercise's comments
+type TodoItem struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Text     string `json:"text"`
+	Required bool   `json:"required"`
+	Blocking bool   `json:"blocking"`
+}
+
+// Position renders the item as file:line:col, the format editors and terminals understand
+func (t TodoItem) Position() string {
+	return fmt.Sprintf("%s:%d:%d", t.File, t.Line, t.Column)
+}
+
+// findTodos parses a Go file and returns the TODO markers found in its comments only
+func findTodos(filePath string) ([]TodoItem, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	var items []TodoItem
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			start := fset.Position(comment.Pos())
+
+			// Block comments can span lines; report each marker on its own line.
+			for i, line := range strings.Split(comment.Text, "\n") {
+				loc := todoMarker.FindStringSubmatchIndex(line)
+				if loc == nil {
+					continue
+				}
+
+				column := loc[0] + 1
+				if i == 0 {
+					column += start.Column - 1
+				}
+
+				items = append(items, TodoItem{
+					File:     filePath,
+					Line:     start.Line + i,
+					Column:   column,
+					Text:     cleanCommentLine(line),
+					Required: loc[2] != -1,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// cleanCommentLine strips comment delimiters so only the learner-facing text remains
+func cleanCommentLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "//")
+	line = strings.TrimPrefix(line, "/*")
+	line = strings.TrimSuffix(line, "*/")
+	line = strings.TrimPrefix(strings.TrimSpace(line), "*")
+	return strings.TrimSpace(line)
+}
+
+// applyTodoPolicy marks which TODO items block completion under the given policy
+func applyTodoPolicy(items []TodoItem, policy string) ([]TodoItem, error) {
+	for i := range items {
+		switch policy {
+		case "", TodoPolicyAll:
+			items[i].Blocking = true
+		case TodoPolicyRequired:
+			items[i].Blocking = items[i].Required
+		case TodoPolicyNone:
+			items[i].Blocking = false
+		default:
+			return nil, fmt.Errorf("unknown todo_policy: %s", policy)
+		}
+	}
+	return items, nil
+}
+
+// formatTodos renders blocking and informational TODOs for the learner
+func formatTodos(items []TodoItem) (blocking bool, message string) {
+	var required, optional []TodoItem
+	for _, item := range items {
+		if item.Blocking {
+			required = append(required, item)
+		} else {
+			optional = append(optional, item)
+		}
+	}
+
+	var b strings.Builder
+	if len(required) > 0 {
+		b.WriteString("❌ TODO comments found. Complete the following tasks:\n\n")
+		for _, item := range required {
+			fmt.Fprintf(&b, "  %s: %s\n", todoLocation(item), item.Text)
+		}
+		b.WriteString("\n💡 Remove or complete all TODO comments to finish this exercise.")
+	} else {
+		b.WriteString("✅ No TODO comments found.")
+	}
+
+	if len(optional) > 0 {
+		b.WriteString("\n\nℹ️  Optional TODOs (not required to finish):\n")
+		for _, item := range optional {
+			fmt.Fprintf(&b, "  %s: %s\n", todoLocation(item), item.Text)
+		}
+	}
+
+	return len(required) > 0, strings.TrimSuffix(b.String(), "\n")
+}
+
+// todoLocation shortens the item position to the file's base name for display
+func todoLocation(item TodoItem) string {
+	item.File = filepath.Base(item.File)
+	return item.Position()
+}
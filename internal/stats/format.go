@@ -0,0 +1,182 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats supported by Write
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// barWidth is the width of the completion bars in the text report
+const barWidth = 20
+
+// Write renders the report in one of the Format constants
+func Write(w io.Writer, report *Report, format string) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, report)
+	case FormatJSON:
+		return WriteJSON(w, report)
+	case FormatCSV:
+		return WriteCSV(w, report)
+	}
+	return fmt.Errorf("unknown format '%s' (use %s, %s or %s)", format, FormatText, FormatJSON, FormatCSV)
+}
+
+// ValidFormat reports whether format is one of the Format constants
+func ValidFormat(format string) bool {
+	return format == FormatText || format == FormatJSON || format == FormatCSV
+}
+
+// WriteText renders the report for people to read, as shown by 'goforgo stats' and the TUI
+func WriteText(w io.Writer, report *Report) error {
+	var b strings.Builder
+
+	scope := "whole course"
+	if report.Track != "" {
+		scope = "track '" + report.Track + "'"
+	}
+	fmt.Fprintf(&b, "📊 Progress (%s): %d/%d (%.1f%% complete)\n", scope, report.Completed, report.Total, report.Percentage)
+	fmt.Fprintf(&b, "🔥 Streak: %s (longest %s)\n", plural(report.CurrentStreak, "day"), plural(report.LongestStreak, "day"))
+
+	if len(report.Daily) > 0 {
+		runs := 0
+		for _, d := range report.Daily {
+			runs += d.Runs
+		}
+		fmt.Fprintf(&b, "📈 Last %s: %s  %s\n", plural(len(report.Daily), "day"), Sparkline(report.Daily), plural(runs, "run"))
+	}
+
+	if len(report.Categories) > 0 {
+		b.WriteString("\n📚 Categories\n")
+		width := 0
+		for _, cat := range report.Categories {
+			width = max(width, len(cat.Category))
+		}
+		for _, cat := range report.Categories {
+			fmt.Fprintf(&b, "  %-*s %s %3d/%d\n", width, cat.Category, bar(cat.Completed, cat.Total), cat.Completed, cat.Total)
+		}
+	}
+
+	if len(report.Difficulties) > 0 {
+		b.WriteString("\n⭐ Difficulty     completed   avg attempts   avg time\n")
+		for _, diff := range report.Difficulties {
+			attempts, avgTime := "-", "-"
+			if diff.AvgAttempts > 0 {
+				attempts = fmt.Sprintf("%.1f", diff.AvgAttempts)
+			}
+			if diff.AvgTimeSeconds > 0 {
+				avgTime = formatSeconds(diff.AvgTimeSeconds)
+			}
+			fmt.Fprintf(&b, "  %-14s %4d/%-5d %13s %10s\n", diff.Label, diff.Completed, diff.Total, attempts, avgTime)
+		}
+	}
+
+	if len(report.MostFailed) > 0 {
+		b.WriteString("\n💥 Most failed\n")
+		for _, ex := range report.MostFailed {
+			fmt.Fprintf(&b, "  %s (%s): %s in %s\n", ex.Name, ex.Category, plural(ex.Failures, "failure"), plural(ex.Runs, "run"))
+		}
+	}
+
+	if report.Estimate.Exercises > 0 {
+		fmt.Fprintf(&b, "\n⏱️  Estimated vs actual, over %s: %s estimated, %s spent (%.1fx)\n",
+			plural(report.Estimate.Exercises, "completed exercise"),
+			formatSeconds(report.Estimate.EstimatedSeconds),
+			formatSeconds(report.Estimate.ActualSeconds),
+			report.Estimate.Ratio)
+		for _, ex := range report.Estimate.Overruns {
+			fmt.Fprintf(&b, "  %s (%s): %s spent, estimated %s\n", ex.Name, ex.Category, formatSeconds(ex.TimeSpentSeconds), formatSeconds(ex.EstimatedSeconds))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON renders the whole report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// csvHeader names the columns written by WriteCSV
+var csvHeader = []string{
+	"category", "name", "title", "difficulty", "completed", "first_seen", "completed_at",
+	"runs", "failures", "hints_viewed", "time_spent_seconds", "estimated_seconds", "failure_kinds",
+}
+
+// WriteCSV renders one row per exercise, so reports from several learners can be
+// concatenated and aggregated in a spreadsheet
+func WriteCSV(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, ex := range report.Exercises {
+		record := []string{
+			ex.Category,
+			ex.Name,
+			ex.Title,
+			strconv.Itoa(ex.Difficulty),
+			strconv.FormatBool(ex.Completed),
+			formatTime(ex.FirstSeen),
+			formatTime(ex.CompletedAt),
+			strconv.Itoa(ex.Runs),
+			strconv.Itoa(ex.Failures),
+			strconv.Itoa(ex.HintsViewed),
+			strconv.FormatInt(ex.TimeSpentSeconds, 10),
+			strconv.FormatInt(ex.EstimatedSeconds, 10),
+			strings.Join(ex.FailureKinds, " "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// bar draws a completion bar like [██████░░░░]
+func bar(completed, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = completed * barWidth / total
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + "]"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// formatSeconds renders a duration in seconds like "1h5m" or "45s"
+func formatSeconds(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	if d >= time.Minute {
+		d = d.Round(time.Minute)
+		return strings.TrimSuffix(d.String(), "0s")
+	}
+	return d.String()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
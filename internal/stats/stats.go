@@ -0,0 +1,338 @@
+// Package stats turns the progress history into learning analytics: completion by
+// category, streaks, attempts and time per difficulty, and daily activity.
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+)
+
+// Report is the analytics for the exercises in scope: the active track, or the whole course
+type Report struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	Track         string    `json:"track,omitempty"`
+	Completed     int       `json:"completed"`
+	Total         int       `json:"total"`
+	Percentage    float64   `json:"percentage"`
+	CurrentStreak int       `json:"current_streak_days"` // Consecutive active days up to today or yesterday
+	LongestStreak int       `json:"longest_streak_days"`
+
+	Categories   []CategoryStats   `json:"categories"`
+	Difficulties []DifficultyStats `json:"difficulties"`
+	MostFailed   []ExerciseStats   `json:"most_failed"`
+	Estimate     EstimateStats     `json:"estimate"`
+	Daily        []DayStats        `json:"daily"` // Oldest first, including days without activity
+	Exercises    []ExerciseStats   `json:"exercises"`
+}
+
+// CategoryStats is the completion of one category
+type CategoryStats struct {
+	Category   string  `json:"category"`
+	Completed  int     `json:"completed"`
+	Total      int     `json:"total"`
+	Percentage float64 `json:"percentage"`
+}
+
+// DifficultyStats averages the completed exercises of one difficulty level. Attempts
+// are validation runs up to and including the passing one.
+type DifficultyStats struct {
+	Difficulty     int     `json:"difficulty"`
+	Label          string  `json:"label"`
+	Completed      int     `json:"completed"`
+	Total          int     `json:"total"`
+	AvgAttempts    float64 `json:"avg_attempts"`     // Over completed exercises with recorded attempts
+	AvgTimeSeconds int64   `json:"avg_time_seconds"` // Over completed exercises with recorded time
+}
+
+// EstimateStats compares estimated_time with the time actually spent, over completed
+// exercises that have both
+type EstimateStats struct {
+	Exercises        int             `json:"exercises"`
+	EstimatedSeconds int64           `json:"estimated_seconds"`
+	ActualSeconds    int64           `json:"actual_seconds"`
+	Ratio            float64         `json:"ratio"` // Actual over estimated; above 1 means slower than estimated
+	Overruns         []ExerciseStats `json:"overruns"`
+}
+
+// DayStats is the activity on one day
+type DayStats struct {
+	Date             string `json:"date"` // exercise.ActivityDateLayout
+	Runs             int    `json:"runs"`
+	Failures         int    `json:"failures"`
+	Completions      int    `json:"completions"`
+	TimeSpentSeconds int64  `json:"time_spent_seconds"`
+}
+
+// ExerciseStats is the recorded history of one exercise
+type ExerciseStats struct {
+	Category         string     `json:"category"`
+	Name             string     `json:"name"`
+	Title            string     `json:"title"`
+	Difficulty       int        `json:"difficulty"`
+	Completed        bool       `json:"completed"`
+	FirstSeen        *time.Time `json:"first_seen,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	Runs             int        `json:"runs"`
+	Failures         int        `json:"failures"`
+	HintsViewed      int        `json:"hints_viewed"`
+	TimeSpentSeconds int64      `json:"time_spent_seconds"`
+	EstimatedSeconds int64      `json:"estimated_seconds"`
+	FailureKinds     []string   `json:"failure_kinds,omitempty"`
+}
+
+// Limits on the lists in a report
+const (
+	mostFailedCount = 5
+	overrunCount    = 5
+)
+
+// Build computes the report for the manager's exercises as of now, with days of daily activity
+func Build(em *exercise.ExerciseManager, now time.Time, days int) *Report {
+	report := &Report{
+		GeneratedAt: now,
+		Categories:  []CategoryStats{},
+		MostFailed:  []ExerciseStats{},
+		Estimate:    EstimateStats{Overruns: []ExerciseStats{}},
+		Exercises:   []ExerciseStats{},
+	}
+	if track := em.ActiveTrack(); track != nil {
+		report.Track = track.Name
+	}
+	report.Completed, report.Total, report.Percentage = em.GetProgressStats()
+
+	categories := make(map[string]*CategoryStats)
+	var categoryOrder []string
+	difficulties := make(map[int]*DifficultyStats)
+	report.Difficulties = []DifficultyStats{}
+	attempts := make(map[int][]int)
+	timed := make(map[int][]time.Duration)
+
+	for _, ex := range em.GetExercises() {
+		h, _ := em.History(ex.Info.Name)
+		stats := exerciseStats(ex, h)
+		report.Exercises = append(report.Exercises, stats)
+
+		cat := categories[ex.Info.Category]
+		if cat == nil {
+			cat = &CategoryStats{Category: ex.Info.Category}
+			categories[ex.Info.Category] = cat
+			categoryOrder = append(categoryOrder, ex.Info.Category)
+		}
+		diff := difficulties[ex.Info.Difficulty]
+		if diff == nil {
+			diff = &DifficultyStats{Difficulty: ex.Info.Difficulty, Label: difficultyLabel(ex.Info.Difficulty)}
+			difficulties[ex.Info.Difficulty] = diff
+		}
+		cat.Total++
+		diff.Total++
+		if !ex.Completed {
+			continue
+		}
+		cat.Completed++
+		diff.Completed++
+		if h.RunsToComplete > 0 {
+			attempts[ex.Info.Difficulty] = append(attempts[ex.Info.Difficulty], h.RunsToComplete)
+		}
+		if h.TimeSpent > 0 {
+			timed[ex.Info.Difficulty] = append(timed[ex.Info.Difficulty], h.TimeSpent)
+		}
+
+		if stats.EstimatedSeconds > 0 && stats.TimeSpentSeconds > 0 {
+			report.Estimate.Exercises++
+			report.Estimate.EstimatedSeconds += stats.EstimatedSeconds
+			report.Estimate.ActualSeconds += stats.TimeSpentSeconds
+			report.Estimate.Overruns = append(report.Estimate.Overruns, stats)
+		}
+	}
+
+	for _, name := range categoryOrder {
+		cat := categories[name]
+		cat.Percentage = percentage(cat.Completed, cat.Total)
+		report.Categories = append(report.Categories, *cat)
+	}
+
+	for level, diff := range difficulties {
+		if runs := attempts[level]; len(runs) > 0 {
+			var total int
+			for _, r := range runs {
+				total += r
+			}
+			diff.AvgAttempts = float64(total) / float64(len(runs))
+		}
+		if durations := timed[level]; len(durations) > 0 {
+			var total time.Duration
+			for _, d := range durations {
+				total += d
+			}
+			diff.AvgTimeSeconds = int64((total / time.Duration(len(durations))).Seconds())
+		}
+		report.Difficulties = append(report.Difficulties, *diff)
+	}
+	sort.Slice(report.Difficulties, func(i, j int) bool {
+		return report.Difficulties[i].Difficulty < report.Difficulties[j].Difficulty
+	})
+
+	for _, stats := range report.Exercises {
+		if stats.Failures > 0 {
+			report.MostFailed = append(report.MostFailed, stats)
+		}
+	}
+	sort.SliceStable(report.MostFailed, func(i, j int) bool {
+		return report.MostFailed[i].Failures > report.MostFailed[j].Failures
+	})
+	report.MostFailed = truncate(report.MostFailed, mostFailedCount)
+
+	if report.Estimate.EstimatedSeconds > 0 {
+		report.Estimate.Ratio = float64(report.Estimate.ActualSeconds) / float64(report.Estimate.EstimatedSeconds)
+	}
+	overruns := report.Estimate.Overruns[:0]
+	for _, stats := range report.Estimate.Overruns {
+		if stats.TimeSpentSeconds > stats.EstimatedSeconds {
+			overruns = append(overruns, stats)
+		}
+	}
+	sort.SliceStable(overruns, func(i, j int) bool {
+		return overruns[i].TimeSpentSeconds-overruns[i].EstimatedSeconds > overruns[j].TimeSpentSeconds-overruns[j].EstimatedSeconds
+	})
+	report.Estimate.Overruns = truncate(overruns, overrunCount)
+
+	activity := em.Activity()
+	report.CurrentStreak, report.LongestStreak = streaks(activity, now)
+	report.Daily = daily(activity, now, days)
+	return report
+}
+
+// exerciseStats converts an exercise and its history
+func exerciseStats(ex *exercise.Exercise, h exercise.ExerciseHistory) ExerciseStats {
+	stats := ExerciseStats{
+		Category:         ex.Info.Category,
+		Name:             ex.Info.Name,
+		Title:            ex.Description.Title,
+		Difficulty:       ex.Info.Difficulty,
+		Completed:        ex.Completed,
+		Runs:             h.Runs,
+		Failures:         h.Failures,
+		HintsViewed:      h.HintsViewed,
+		TimeSpentSeconds: int64(h.TimeSpent.Seconds()),
+		FailureKinds:     h.FailureKinds,
+	}
+	if !h.FirstSeen.IsZero() {
+		stats.FirstSeen = &h.FirstSeen
+	}
+	if !h.CompletedAt.IsZero() {
+		stats.CompletedAt = &h.CompletedAt
+	}
+	if estimate, err := time.ParseDuration(ex.Info.EstimatedTime); err == nil {
+		stats.EstimatedSeconds = int64(estimate.Seconds())
+	}
+	return stats
+}
+
+// active reports whether anything happened on a day
+func active(a exercise.DailyActivity) bool {
+	return a.Runs > 0 || a.Completions > 0
+}
+
+// streaks returns the current and longest runs of consecutive active days. The current
+// streak still counts when today has no activity yet but yesterday had.
+func streaks(activity map[string]exercise.DailyActivity, now time.Time) (current, longest int) {
+	var days []time.Time
+	for day, a := range activity {
+		if t, err := time.ParseInLocation(exercise.ActivityDateLayout, day, now.Location()); err == nil && active(a) {
+			days = append(days, t)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	day := now
+	if !active(activity[day.Format(exercise.ActivityDateLayout)]) {
+		day = day.AddDate(0, 0, -1)
+	}
+	for active(activity[day.Format(exercise.ActivityDateLayout)]) {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+// daily returns the activity of the last n days, ending today
+func daily(activity map[string]exercise.DailyActivity, now time.Time, n int) []DayStats {
+	out := make([]DayStats, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		date := now.AddDate(0, 0, -i).Format(exercise.ActivityDateLayout)
+		a := activity[date]
+		out = append(out, DayStats{
+			Date:             date,
+			Runs:             a.Runs,
+			Failures:         a.Failures,
+			Completions:      a.Completions,
+			TimeSpentSeconds: int64(a.TimeSpent.Seconds()),
+		})
+	}
+	return out
+}
+
+// Sparkline renders daily runs as a row of block characters, one per day, scaled to the
+// busiest day. Days without runs are shown as a dot.
+func Sparkline(days []DayStats) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+	peak := 0
+	for _, d := range days {
+		peak = max(peak, d.Runs)
+	}
+	out := make([]rune, len(days))
+	for i, d := range days {
+		switch {
+		case d.Runs == 0:
+			out[i] = '·'
+		case peak == 1:
+			out[i] = levels[len(levels)-1]
+		default:
+			out[i] = levels[(d.Runs-1)*(len(levels)-1)/(peak-1)]
+		}
+	}
+	return string(out)
+}
+
+func percentage(completed, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(completed) / float64(total) * 100
+}
+
+func truncate(list []ExerciseStats, n int) []ExerciseStats {
+	if len(list) > n {
+		return list[:n]
+	}
+	return list
+}
+
+// difficultyLabel names a difficulty level like Exercise.GetDifficultyString, without stars
+func difficultyLabel(difficulty int) string {
+	switch difficulty {
+	case 1:
+		return "Beginner"
+	case 2:
+		return "Easy"
+	case 3:
+		return "Medium"
+	case 4:
+		return "Hard"
+	case 5:
+		return "Expert"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,221 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/testutil"
+)
+
+// learnerManager loads three exercises for a learner who has done hello and vars, but not loops
+func learnerManager(t *testing.T) *exercise.ExerciseManager {
+	t.Helper()
+	tempDir := t.TempDir()
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "01_basics", Name: "hello", Difficulty: 1, Fields: "estimated_time = \"5m\"\n"})
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "01_basics", Name: "vars", Difficulty: 2, Fields: "estimated_time = \"10m\"\n"})
+	testutil.WriteExercise(t, tempDir, testutil.Exercise{Category: "02_control", Name: "loops", Difficulty: 2, Fields: "estimated_time = \"10m\"\n"})
+
+	em := exercise.NewExerciseManager(tempDir)
+	em.Quiet = true
+	if err := em.LoadExercises(); err != nil {
+		t.Fatalf("Failed to load exercises: %v", err)
+	}
+
+	steps := []struct {
+		name    string
+		success bool
+		kind    string
+	}{
+		{"hello", true, ""},
+		{"vars", false, exercise.FailureBuild},
+		{"vars", false, exercise.FailureTest},
+		{"vars", true, ""},
+		{"loops", false, exercise.FailureBuild},
+	}
+	for _, step := range steps {
+		if err := em.RecordRun(step.name, step.success, step.kind); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+	for _, name := range []string{"hello", "vars"} {
+		if err := em.MarkExerciseCompleted(name); err != nil {
+			t.Fatalf("MarkExerciseCompleted failed: %v", err)
+		}
+	}
+	if err := em.AddTimeSpent("hello", 3*time.Minute); err != nil {
+		t.Fatalf("AddTimeSpent failed: %v", err)
+	}
+	if err := em.AddTimeSpent("vars", 20*time.Minute); err != nil {
+		t.Fatalf("AddTimeSpent failed: %v", err)
+	}
+	return em
+}
+
+func TestBuild(t *testing.T) {
+	em := learnerManager(t)
+	report := Build(em, time.Now(), 7)
+
+	if report.Completed != 2 || report.Total != 3 {
+		t.Errorf("Expected 2/3 completed, got %d/%d", report.Completed, report.Total)
+	}
+	if len(report.Categories) != 2 || report.Categories[0].Category != "01_basics" || report.Categories[0].Completed != 2 || report.Categories[1].Completed != 0 {
+		t.Errorf("Unexpected categories: %+v", report.Categories)
+	}
+
+	if len(report.Difficulties) != 2 {
+		t.Fatalf("Expected 2 difficulty levels, got %+v", report.Difficulties)
+	}
+	medium := report.Difficulties[1]
+	if medium.Difficulty != 2 || medium.Completed != 1 || medium.Total != 2 || medium.AvgAttempts != 3 || medium.AvgTimeSeconds != 1200 {
+		t.Errorf("Unexpected difficulty 2 stats: %+v", medium)
+	}
+
+	if len(report.MostFailed) != 2 || report.MostFailed[0].Name != "vars" || report.MostFailed[0].Failures != 2 {
+		t.Errorf("Expected vars to have failed most, got %+v", report.MostFailed)
+	}
+
+	estimate := report.Estimate
+	if estimate.Exercises != 2 || estimate.EstimatedSeconds != 900 || estimate.ActualSeconds != 1380 {
+		t.Errorf("Unexpected estimate: %+v", estimate)
+	}
+	if len(estimate.Overruns) != 1 || estimate.Overruns[0].Name != "vars" {
+		t.Errorf("Expected only vars to overrun, got %+v", estimate.Overruns)
+	}
+
+	if report.CurrentStreak != 1 || report.LongestStreak != 1 {
+		t.Errorf("Expected a one day streak, got %d (longest %d)", report.CurrentStreak, report.LongestStreak)
+	}
+	if len(report.Daily) != 7 {
+		t.Fatalf("Expected 7 days of activity, got %d", len(report.Daily))
+	}
+	today := report.Daily[6]
+	if today.Runs != 5 || today.Failures != 3 || today.Completions != 2 || today.TimeSpentSeconds != 1380 {
+		t.Errorf("Unexpected activity today: %+v", today)
+	}
+
+	// Runs after the passing one aren't attempts at it
+	for range 2 {
+		if err := em.RecordRun("vars", true, ""); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+	if medium := Build(em, time.Now(), 7).Difficulties[1]; medium.AvgAttempts != 3 {
+		t.Errorf("Expected 3 attempts at vars after running it again, got %v", medium.AvgAttempts)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := func(offset int) string {
+		return now.AddDate(0, 0, offset).Format(exercise.ActivityDateLayout)
+	}
+	tests := []struct {
+		name             string
+		activity         map[string]exercise.DailyActivity
+		current, longest int
+	}{
+		{"no activity", nil, 0, 0},
+		{
+			"active through today",
+			map[string]exercise.DailyActivity{day(0): {Runs: 1}, day(-1): {Runs: 2}, day(-3): {Runs: 1}},
+			2, 2,
+		},
+		{
+			"not yet active today",
+			map[string]exercise.DailyActivity{day(-1): {Runs: 1}, day(-2): {Completions: 1}},
+			2, 2,
+		},
+		{
+			"broken streak",
+			map[string]exercise.DailyActivity{day(-2): {Runs: 1}, day(-5): {Runs: 1}, day(-6): {Runs: 1}, day(-7): {Runs: 1}},
+			0, 3,
+		},
+		{
+			"idle days don't count",
+			map[string]exercise.DailyActivity{day(0): {Runs: 1}, day(-1): {TimeSpent: time.Minute}},
+			1, 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := streaks(tt.activity, now)
+			if current != tt.current || longest != tt.longest {
+				t.Errorf("streaks() = %d, %d; want %d, %d", current, longest, tt.current, tt.longest)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	days := func(runs ...int) []DayStats {
+		var out []DayStats
+		for _, r := range runs {
+			out = append(out, DayStats{Runs: r})
+		}
+		return out
+	}
+	tests := []struct {
+		runs []DayStats
+		want string
+	}{
+		{days(), ""},
+		{days(0, 0), "··"},
+		{days(0, 1), "·█"},
+		{days(1, 4, 8, 0), "▁▄█·"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.runs); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.runs, got, tt.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	report := Build(learnerManager(t), time.Now(), 7)
+
+	var text bytes.Buffer
+	if err := Write(&text, report, FormatText); err != nil {
+		t.Fatalf("Write text failed: %v", err)
+	}
+	for _, want := range []string{"2/3", "01_basics", "vars (01_basics): 2 failures in 3 runs", "Estimated vs actual"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected text report to contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var jsonOut bytes.Buffer
+	if err := Write(&jsonOut, report, FormatJSON); err != nil {
+		t.Fatalf("Write json failed: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if decoded.Completed != 2 || len(decoded.Exercises) != 3 {
+		t.Errorf("Unexpected decoded report: %+v", decoded)
+	}
+
+	var csvOut bytes.Buffer
+	if err := Write(&csvOut, report, FormatCSV); err != nil {
+		t.Fatalf("Write csv failed: %v", err)
+	}
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 4 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("Expected a header and 3 rows, got %v", records)
+	}
+	if vars := records[2]; vars[1] != "vars" || vars[4] != "true" || vars[8] != "2" || vars[12] != "build test" {
+		t.Errorf("Unexpected row for vars: %v", vars)
+	}
+
+	if err := Write(&bytes.Buffer{}, report, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,51 @@
+// Package testutil writes the exercise fixtures shared by the tests of several packages.
+// It only writes files, so the exercise package's own tests can use it too.
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// Exercise is a build-mode exercise fixture. Unset fields get defaults.
+type Exercise struct {
+	Category   string
+	Name       string
+	Difficulty int    // 1 if unset
+	Source     string // The exercise's Go file; an empty main if unset
+	Fields     string // Extra [exercise] keys, e.g. "estimated_time = \"5m\"\n"
+	Extra      string // TOML appended after the [validation] table
+}
+
+// WriteExercise writes ex's Go file and TOML under root/exercises
+func WriteExercise(t testing.TB, root string, ex Exercise) {
+	t.Helper()
+	dir := filepath.Join(root, "exercises", ex.Category)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create directory for %s: %v", ex.Name, err)
+	}
+	source := ex.Source
+	if source == "" {
+		source = "package main\n\nfunc main() {}\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, ex.Name+".go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write Go file for %s: %v", ex.Name, err)
+	}
+	difficulty := max(ex.Difficulty, 1)
+	tomlContent := `[exercise]
+name = "` + ex.Name + `"
+category = "` + ex.Category + `"
+difficulty = ` + strconv.Itoa(difficulty) + `
+` + ex.Fields + `
+[description]
+title = "Test ` + ex.Name + `"
+
+[validation]
+mode = "build"
+` + ex.Extra
+	if err := os.WriteFile(filepath.Join(dir, ex.Name+".toml"), []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("Failed to write TOML file for %s: %v", ex.Name, err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package textdiff computes unified line diffs, such as expected against actual
+// program output or a pristine exercise file against the learner's copy.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each hunk.
+const contextLines = 3
+
+// maxCells caps the LCS table size so huge inputs don't stall the caller.
+const maxCells = 4_000_000
+
+// Op identifies the kind of a line in a unified diff.
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+	Hunk
+)
+
+// Line is a single rendered line of a unified diff
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// String renders the line with its unified diff prefix
+func (l Line) String() string {
+	switch l.Op {
+	case Delete:
+		return "-" + l.Text
+	case Insert:
+		return "+" + l.Text
+	case Hunk:
+		return l.Text
+	default:
+		return " " + l.Text
+	}
+}
+
+// Unified computes a line diff of expected vs actual and groups it into unified hunks
+// under "--- expected" and "+++ actual" headers. eq decides whether expected line i
+// matches actual line j; nil compares the lines for equality. It returns nil when
+// every line matches.
+func Unified(expected, actual []string, eq func(i, j int, e, a string) bool) []Line {
+	if eq == nil {
+		eq = func(_, _ int, e, a string) bool { return e == a }
+	}
+	ops := diffOps(expected, actual, eq)
+
+	// Locate the changed lines so we can emit only those with surrounding context.
+	var changed []int
+	for i, op := range ops {
+		if op.Op != Equal {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	lines := []Line{
+		{Op: Hunk, Text: "--- expected"},
+		{Op: Hunk, Text: "+++ actual"},
+	}
+
+	for k := 0; k < len(changed); {
+		start := max(0, changed[k]-contextLines)
+		end := changed[k] + contextLines + 1
+
+		// Merge subsequent changes whose context overlaps into the same hunk.
+		for k++; k < len(changed) && changed[k]-contextLines <= end; k++ {
+			end = changed[k] + contextLines + 1
+		}
+		end = min(end, len(ops))
+
+		expStart, actStart := ops[start].expLine, ops[start].actLine
+		expCount, actCount := 0, 0
+		for _, op := range ops[start:end] {
+			if op.Op != Insert {
+				expCount++
+			}
+			if op.Op != Delete {
+				actCount++
+			}
+		}
+
+		lines = append(lines, Line{
+			Op:   Hunk,
+			Text: fmt.Sprintf("@@ -%d,%d +%d,%d @@", expStart+1, expCount, actStart+1, actCount),
+		})
+		for _, op := range ops[start:end] {
+			lines = append(lines, op.Line)
+		}
+	}
+
+	return lines
+}
+
+// Format renders diff lines as plain unified diff text
+func Format(lines []Line) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(line.String())
+	}
+	return b.String()
+}
+
+// diffOp is a Line annotated with its position in the expected and actual inputs
+type diffOp struct {
+	Line
+	expLine int
+	actLine int
+}
+
+// diffOps produces the full edit script between expected and actual using a longest common subsequence.
+func diffOps(expected, actual []string, eq func(i, j int, e, a string) bool) []diffOp {
+	n, m := len(expected), len(actual)
+	var ops []diffOp
+
+	if n*m > maxCells {
+		// Too large for an LCS table; report everything as replaced.
+		for i, line := range expected {
+			ops = append(ops, diffOp{Line{Delete, line}, i, 0})
+		}
+		for j, line := range actual {
+			ops = append(ops, diffOp{Line{Insert, line}, n, j})
+		}
+		return ops
+	}
+
+	// lcs[i][j] holds the LCS length of expected[i:] and actual[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j, expected[i], actual[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case eq(i, j, expected[i], actual[j]):
+			ops = append(ops, diffOp{Line{Equal, actual[j]}, i, j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{Line{Delete, expected[i]}, i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{Line{Insert, actual[j]}, i, j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{Line{Delete, expected[i]}, i, j})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{Line{Insert, actual[j]}, i, j})
+	}
+
+	return ops
+}
@@ -0,0 +1,25 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	diff := Unified([]string{"a", "b", "c"}, []string{"a", "x", "c"}, nil)
+	got := Format(diff)
+	want := "--- expected\n+++ actual\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c"
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if diff := Unified([]string{"a"}, []string{"a"}, nil); diff != nil {
+		t.Errorf("Expected no diff for equal lines, got %v", diff)
+	}
+
+	// A custom comparison decides which lines match
+	fold := func(_, _ int, e, a string) bool { return strings.EqualFold(e, a) }
+	if diff := Unified([]string{"A", "b"}, []string{"a", "B"}, fold); diff != nil {
+		t.Errorf("Expected lines equal but for case to match, got %v", diff)
+	}
+}
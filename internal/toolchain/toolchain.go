@@ -0,0 +1,76 @@
+// Package toolchain detects the installed Go toolchain and checks exercises'
+// minimum Go version requirements against it.
+package toolchain
+
+import (
+	"fmt"
+	"go/version"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+var (
+	detectOnce sync.Once
+	detected   string
+	detectErr  error
+)
+
+// Detect returns the installed toolchain version, e.g. "go1.24.5". The go
+// command is only invoked once per process; later calls return the cached result.
+func Detect() (string, error) {
+	detectOnce.Do(func() {
+		out, err := exec.Command("go", "env", "GOVERSION").Output()
+		if err != nil {
+			detectErr = fmt.Errorf("failed to detect Go toolchain: %w", err)
+			return
+		}
+		detected = parseGoVersion(string(out))
+	})
+	return detected, detectErr
+}
+
+// parseGoVersion reduces GOVERSION output to a comparable version. Experiment
+// suffixes ("go1.25.0 X:nodwarf5") are dropped and development builds
+// ("devel go1.26-abcdef ...") map to their language version.
+func parseGoVersion(out string) string {
+	out = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(out), "devel "))
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return ""
+	}
+	v, _, _ := strings.Cut(fields[0], "-")
+	return v
+}
+
+// Normalize turns an exercise's go_version ("1.16+", ">=1.21", "go1.23") into
+// a bare version such as "1.16". It returns "" when there is no requirement.
+func Normalize(requirement string) string {
+	v := strings.TrimSpace(requirement)
+	v = strings.TrimPrefix(v, ">=")
+	v = strings.TrimSuffix(strings.TrimSpace(v), "+")
+	v = strings.TrimPrefix(strings.TrimSpace(v), "go")
+	return strings.TrimSpace(v)
+}
+
+// Satisfies reports whether the installed toolchain meets a go_version requirement.
+// Unparseable versions on either side are treated as satisfied so a typo in a
+// TOML file never locks a learner out.
+func Satisfies(installed, requirement string) bool {
+	required := "go" + Normalize(requirement)
+	if required == "go" || !version.IsValid(required) || !version.IsValid(installed) {
+		return true
+	}
+	return version.Compare(installed, required) >= 0
+}
+
+// Newer returns whichever of two bare versions ("1.24", "1.23.1") is newer
+func Newer(a, b string) string {
+	if !version.IsValid("go" + b) {
+		return a
+	}
+	if !version.IsValid("go"+a) || version.Compare("go"+b, "go"+a) > 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,50 @@
+package toolchain
+
+import "testing"
+
+func TestParseGoVersion(t *testing.T) {
+	tests := map[string]string{
+		"go1.24.5\n":                   "go1.24.5",
+		"go1.25.0 X:nodwarf5":          "go1.25.0",
+		"devel go1.26-abcdef123 +0000": "go1.26",
+		"":                             "",
+	}
+	for in, want := range tests {
+		if got := parseGoVersion(in); got != want {
+			t.Errorf("parseGoVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		installed   string
+		requirement string
+		want        bool
+	}{
+		{"go1.24.5", "1.16+", true},
+		{"go1.24.5", "1.24", true},
+		{"go1.24.5", ">=1.25", false},
+		{"go1.22.0", "go1.23", false},
+		{"go1.24.5", "", true},
+		{"go1.24.5", "not-a-version", true},
+		{"", "1.99+", true},
+	}
+	for _, tt := range tests {
+		if got := Satisfies(tt.installed, tt.requirement); got != tt.want {
+			t.Errorf("Satisfies(%q, %q) = %v, want %v", tt.installed, tt.requirement, got, tt.want)
+		}
+	}
+}
+
+func TestNewer(t *testing.T) {
+	if got := Newer("1.24", "1.16"); got != "1.24" {
+		t.Errorf("Newer(1.24, 1.16) = %s", got)
+	}
+	if got := Newer("1.24", "1.25"); got != "1.25" {
+		t.Errorf("Newer(1.24, 1.25) = %s", got)
+	}
+	if got := Newer("1.24", ""); got != "1.24" {
+		t.Errorf("Newer(1.24, \"\") = %s", got)
+	}
+}
@@ -2,6 +2,9 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stonecharioteer/goforgo/internal/exercise"
 	"github.com/stonecharioteer/goforgo/internal/runner"
+	"github.com/stonecharioteer/goforgo/internal/stats"
 	"github.com/stonecharioteer/goforgo/internal/watcher"
 )
 
@@ -22,6 +26,7 @@ const (
 	ViewList
 	ViewHint
 	ViewOutput
+	ViewStats
 )
 
 // Model represents the TUI application state
@@ -42,6 +47,7 @@ type Model struct {
 	watcher          *watcher.Watcher
 	watcherErr       error
 	watcherListening bool
+	progressPath     string // Absolute path of the progress file, watched for other goforgo processes' saves
 
 	// UI state
 	viewMode ViewMode
@@ -62,6 +68,10 @@ type Model struct {
 	outputScrollPos  int // Current scroll position in output view
 	outputViewHeight int // Available height for output content
 
+	// Stats view state
+	statsLines     []string // Rendered report, built when the view opens
+	statsScrollPos int
+
 	// Progress and statistics
 	// Counts are now calculated dynamically via exerciseManager methods
 
@@ -76,6 +86,11 @@ type Model struct {
 	// Skip TODO check mode
 	skipTodoCheck bool // When true, TODO comments do not block exercise completion
 
+	// Time on exercise, flushed to the progress history when the exercise changes
+	timedExercise *exercise.Exercise
+	timedSince    time.Time
+	pendingTime   time.Duration
+
 	// Messages and status
 	statusMessage string
 	updateNotice  string
@@ -106,7 +121,19 @@ func NewModel(exerciseManager *exercise.ExerciseManager, runner *runner.Runner)
 		runner:          runner,
 		viewMode:        ViewSplash,
 		splashFrame:     0,
+		statusMessage:   warningStatus(exerciseManager.Warnings()),
+	}
+}
+
+// warningStatus sums up warnings for the status line, "" when there are none
+func warningStatus(warnings []string) string {
+	switch len(warnings) {
+	case 0:
+		return ""
+	case 1:
+		return "⚠️  " + warnings[0]
 	}
+	return fmt.Sprintf("⚠️  %s (and %d more)", warnings[0], len(warnings)-1)
 }
 
 // SetUpdateNotice sets a startup update notice shown in the UI footer.
@@ -117,7 +144,7 @@ func (m *Model) SetUpdateNotice(notice string) {
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
-		m.runCurrentExercise(),
+		m.checkCurrentExercise(),
 		m.startFileWatcher(),
 		m.splashTick(), // Start splash animation
 	)
@@ -125,6 +152,14 @@ func (m *Model) Init() tea.Cmd {
 
 // Update handles messages and state changes
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.trackTime()
+	model, cmd := m.update(msg)
+	m.switchTimedExercise()
+	return model, cmd
+}
+
+// update handles a single message
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -140,6 +175,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.isRunning = false
 		m.statusMessage = ""
 
+		// Record the run in the exercise's history, along with time spent so far
+		if ex := msg.exercise; ex != nil && msg.record {
+			kind := runner.FailureKind(ex, msg.result)
+			if err := m.exerciseManager.RecordRun(ex.Info.Name, msg.result.Success, kind); err != nil {
+				m.statusMessage = fmt.Sprintf("Failed to save progress: %v", err)
+			}
+			m.flushTime()
+		}
+
 		// Mark exercise as completed if successful and not already completed
 		if msg.result.Success && m.currentExercise != nil && !m.currentExercise.Completed {
 			if err := m.exerciseManager.MarkExerciseCompleted(m.currentExercise.Info.Name); err == nil {
@@ -171,6 +215,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.armFileWatcher()
 
+	case progressChangedMsg:
+		m.watcherListening = false
+		changed, err := m.exerciseManager.ReloadProgress()
+		if changed {
+			m.refreshExercises()
+			m.statusMessage = "Progress updated by another goforgo process"
+		}
+		if err != nil {
+			m.statusMessage = warningStatus([]string{err.Error()})
+		}
+		return m, m.armFileWatcher()
+
 	case watcherErrorMsg:
 		m.watcherListening = false
 		m.watcherErr = msg.err
@@ -204,6 +260,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMessage = fmt.Sprintf("Synced: %d/%d complete", msg.completed, msg.total)
 		return m, nil
 
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Editor failed: %v", msg.err)
+			return m, nil
+		}
+		return m, m.runCurrentExercise()
+
 	case statusMsg:
 		m.statusMessage = msg.message
 		return m, nil
@@ -220,7 +283,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "n":
 		// Next exercise
-		if m.viewMode == ViewHint || m.viewMode == ViewList {
+		if m.viewMode == ViewHint || m.viewMode == ViewList || m.viewMode == ViewStats {
 			m.viewMode = ViewMain
 			return m, nil
 		}
@@ -228,7 +291,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "p":
 		// Previous exercise
-		if m.viewMode == ViewHint || m.viewMode == ViewList {
+		if m.viewMode == ViewHint || m.viewMode == ViewList || m.viewMode == ViewStats {
 			m.viewMode = ViewMain
 			return m, nil
 		}
@@ -239,10 +302,12 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.viewMode != ViewHint {
 			m.currentHintLevel = 1
 			m.viewMode = ViewHint
+			m.recordHintViewed()
 		} else {
 			maxLevel := m.getMaxHintLevel()
 			if m.currentHintLevel < maxLevel {
 				m.currentHintLevel++
+				m.recordHintViewed()
 			} else {
 				m.viewMode = ViewMain
 				m.currentHintLevel = 0
@@ -263,6 +328,19 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "i":
+		if m.filterMode {
+			m.filterText += "i"
+			return m, nil
+		}
+		// Toggle the learning statistics
+		if m.viewMode == ViewStats {
+			m.viewMode = ViewMain
+		} else if m.viewMode != ViewSplash && m.viewMode != ViewWelcome {
+			m.openStats()
+		}
+		return m, nil
+
 	case "a":
 		// Toggle auto-advance mode
 		if m.viewMode == ViewMain {
@@ -286,7 +364,21 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else {
 				m.statusMessage = "Skip TODO check: OFF"
 			}
-			return m, m.runCurrentExercise()
+			return m, m.checkCurrentExercise()
+		}
+		return m, nil
+
+	case "e":
+		// Open the exercise in $EDITOR, jumping to the first blocking TODO
+		if (m.viewMode == ViewMain || m.viewMode == ViewOutput) && m.currentExercise != nil {
+			return m, m.openInEditor()
+		}
+		return m, nil
+
+	case "f":
+		// Rewrite the exercise file the way gofmt would
+		if (m.viewMode == ViewMain || m.viewMode == ViewOutput) && m.currentExercise != nil {
+			return m, m.formatCurrentFile()
 		}
 		return m, nil
 
@@ -320,6 +412,9 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.viewMode == ViewOutput {
 			return m, m.scrollOutput(-1)
 		}
+		if m.viewMode == ViewStats {
+			m.scrollStats(-1)
+		}
 		return m, nil
 
 	case "down", "j":
@@ -330,6 +425,9 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.viewMode == ViewOutput {
 			return m, m.scrollOutput(1)
 		}
+		if m.viewMode == ViewStats {
+			m.scrollStats(1)
+		}
 		return m, nil
 
 	case "ctrl+u":
@@ -510,7 +608,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.viewMode = ViewMain
 				m.filterMode = false
 				m.filterText = ""
-				return m, m.runCurrentExercise()
+				return m, m.checkCurrentExercise()
 			}
 		}
 		if msg.String() == "esc" {
@@ -541,14 +639,19 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Handle text input in filter mode
 		if m.filterMode && len(key) == 1 {
-			// Only allow alphanumeric characters, underscore, and space
+			// Only allow alphanumeric characters, underscore, hyphen (used in tags), and space
 			if (key >= "a" && key <= "z") || (key >= "A" && key <= "Z") ||
-				(key >= "0" && key <= "9") || key == "_" || key == " " {
+				(key >= "0" && key <= "9") || key == "_" || key == "-" || key == " " {
 				m.filterText += key
 				return m, nil
 			}
 		}
 
+		// Jump to a related exercise listed in the hint view
+		if m.viewMode == ViewHint && len(key) == 1 && key >= "1" && key <= "9" {
+			return m, m.openRelated(int(key[0] - '1'))
+		}
+
 		// Handle numeric prefix for vim-style {count} motions in list/output views
 		if !m.filterMode && (m.viewMode == ViewList || m.viewMode == ViewOutput) {
 			if key >= "0" && key <= "9" && (m.pendingCount > 0 || key != "0") {
@@ -585,6 +688,8 @@ func (m *Model) View() string {
 		return m.renderHint()
 	case ViewOutput:
 		return m.renderOutput()
+	case ViewStats:
+		return m.renderStats()
 	default:
 		return m.renderMain()
 	}
@@ -592,7 +697,9 @@ func (m *Model) View() string {
 
 // Custom messages for the tea program
 type exerciseResultMsg struct {
-	result *runner.Result
+	exercise *exercise.Exercise // The exercise that was run
+	result   *runner.Result
+	record   bool // Whether the run counts as an attempt in the exercise's history
 }
 
 type exerciseRunningMsg struct{}
@@ -601,6 +708,9 @@ type fileChangedMsg struct {
 	path string
 }
 
+// progressChangedMsg reports that the progress file changed on disk
+type progressChangedMsg struct{}
+
 type watcherErrorMsg struct {
 	err error
 }
@@ -611,6 +721,10 @@ type statusMsg struct {
 	message string
 }
 
+type editorFinishedMsg struct {
+	err error
+}
+
 type splashTickMsg struct{}
 
 type autoAdvanceMsg struct{}
@@ -621,16 +735,30 @@ type syncResultMsg struct {
 }
 
 // Commands
+
+// runCurrentExercise runs the current exercise after the learner changed it or asked
+// for a run, recording the run as an attempt
 func (m *Model) runCurrentExercise() tea.Cmd {
+	return m.runExercise(true)
+}
+
+// checkCurrentExercise runs the current exercise to show its state, without recording
+// the run: opening an exercise isn't an attempt at it
+func (m *Model) checkCurrentExercise() tea.Cmd {
+	return m.runExercise(false)
+}
+
+func (m *Model) runExercise(record bool) tea.Cmd {
 	if m.currentExercise == nil {
 		return nil
 	}
 
+	ex := m.currentExercise
 	return tea.Batch(
 		func() tea.Msg { return exerciseRunningMsg{} },
 		func() tea.Msg {
-			result, _ := m.runner.RunExercise(m.currentExercise)
-			return exerciseResultMsg{result: result}
+			result, _ := m.runner.RunExercise(ex)
+			return exerciseResultMsg{exercise: ex, result: result, record: record}
 		},
 	)
 }
@@ -640,7 +768,7 @@ func (m *Model) nextExercise() tea.Cmd {
 		m.currentIndex++
 		m.currentExercise = m.exercises[m.currentIndex]
 		m.currentHintLevel = 0 // Reset hint level for new exercise
-		return m.runCurrentExercise()
+		return m.checkCurrentExercise()
 	}
 	return func() tea.Msg {
 		return statusMsg{message: "You've reached the last exercise!"}
@@ -652,13 +780,153 @@ func (m *Model) previousExercise() tea.Cmd {
 		m.currentIndex--
 		m.currentExercise = m.exercises[m.currentIndex]
 		m.currentHintLevel = 0 // Reset hint level for new exercise
-		return m.runCurrentExercise()
+		return m.checkCurrentExercise()
 	}
 	return func() tea.Msg {
 		return statusMsg{message: "You're at the first exercise!"}
 	}
 }
 
+// maxTimeGap caps the time counted between two consecutive messages, so a TUI left open
+// and idle doesn't count as time spent on the exercise
+const maxTimeGap = 5 * time.Minute
+
+// trackTime adds the time since the last message to the exercise being timed. Nothing
+// is counted on the splash and welcome screens.
+func (m *Model) trackTime() {
+	now := time.Now()
+	if m.timedExercise != nil && !m.timedSince.IsZero() && m.viewMode != ViewSplash && m.viewMode != ViewWelcome {
+		gap := now.Sub(m.timedSince)
+		if gap > maxTimeGap {
+			gap = maxTimeGap
+		}
+		m.pendingTime += gap
+	}
+	m.timedSince = now
+}
+
+// switchTimedExercise starts timing the current exercise if it changed, saving the time
+// spent on the previous one and recording the new one as seen
+func (m *Model) switchTimedExercise() {
+	if m.currentExercise == m.timedExercise {
+		return
+	}
+	m.flushTime()
+	m.timedExercise = m.currentExercise
+	if m.timedExercise != nil {
+		_ = m.exerciseManager.MarkSeen(m.timedExercise.Info.Name)
+	}
+}
+
+// flushTime saves the time accumulated on the timed exercise to its history
+func (m *Model) flushTime() {
+	if m.timedExercise == nil || m.pendingTime == 0 {
+		return
+	}
+	if err := m.exerciseManager.AddTimeSpent(m.timedExercise.Info.Name, m.pendingTime); err == nil {
+		m.pendingTime = 0
+	}
+}
+
+// recordHintViewed records a newly revealed hint level in the exercise's history
+func (m *Model) recordHintViewed() {
+	if m.currentExercise == nil {
+		return
+	}
+	if err := m.exerciseManager.RecordHintViewed(m.currentExercise.Info.Name); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save progress: %v", err)
+	}
+}
+
+// openRelated switches to the current exercise's i-th related exercise
+func (m *Model) openRelated(i int) tea.Cmd {
+	if m.currentExercise == nil || i >= len(m.currentExercise.Related) {
+		return nil
+	}
+	target := m.currentExercise.Related[i]
+	for idx, ex := range m.exercises {
+		if ex == target {
+			m.currentIndex = idx
+			m.currentExercise = ex
+			m.currentHintLevel = 0
+			m.viewMode = ViewMain
+			return m.checkCurrentExercise()
+		}
+	}
+	return nil
+}
+
+// openInEditor suspends the TUI and opens the current exercise at its first TODO
+func (m *Model) openInEditor() tea.Cmd {
+	path := m.currentExercise.FilePath
+	line, column := 1, 1
+	if todo := m.firstTodo(); todo != nil {
+		path, line, column = todo.File, todo.Line, todo.Column
+	}
+
+	cmd := editorCommand(path, line, column)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// formatCurrentFile gofmts the current exercise file in place and re-runs it if it changed
+func (m *Model) formatCurrentFile() tea.Cmd {
+	path := m.currentExercise.FilePath
+	changed, err := runner.FormatFile(path)
+	switch {
+	case err != nil:
+		m.statusMessage = fmt.Sprintf("Format failed: %v", err)
+		return nil
+	case !changed:
+		m.statusMessage = filepath.Base(path) + " is already formatted"
+		return nil
+	}
+	m.statusMessage = "Formatted " + filepath.Base(path)
+	return m.runCurrentExercise()
+}
+
+// firstTodo returns the first blocking TODO from the last run, or the first TODO if none block
+func (m *Model) firstTodo() *runner.TodoItem {
+	if m.lastResult == nil || len(m.lastResult.Validation.TodoItems) == 0 {
+		return nil
+	}
+	items := m.lastResult.Validation.TodoItems
+	for i := range items {
+		if items[i].Blocking {
+			return &items[i]
+		}
+	}
+	return &items[0]
+}
+
+// editorCommand builds a command that opens path at line:column in $VISUAL or $EDITOR
+func editorCommand(path string, line, column int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	fields := strings.Fields(editor)
+	name, args := fields[0], fields[1:]
+	position := fmt.Sprintf("%s:%d:%d", path, line, column)
+
+	// Editors disagree on how to receive a position; cover the common ones.
+	switch filepath.Base(name) {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "--goto", position)
+	case "subl", "hx", "helix", "zed":
+		args = append(args, position)
+	default:
+		args = append(args, fmt.Sprintf("+%d", line), path)
+	}
+
+	return exec.Command(name, args...)
+}
+
 func (m *Model) startFileWatcher() tea.Cmd {
 	w, err := watcher.NewWatcher()
 	if err != nil {
@@ -677,6 +945,16 @@ func (m *Model) startFileWatcher() tea.Cmd {
 		}
 	}
 
+	// Watch the progress file's directory too, since saves replace the file. Progress
+	// kept on a server has no file to watch.
+	if m.exerciseManager.ProgressPath != "" {
+		if path, err := filepath.Abs(m.exerciseManager.ProgressPath); err == nil {
+			if err := w.Add(filepath.Dir(path)); err == nil {
+				m.progressPath = path
+			}
+		}
+	}
+
 	// Start watching for file changes
 	return m.armFileWatcher()
 }
@@ -704,6 +982,9 @@ func (m *Model) waitForFileChange(w *watcher.Watcher) tea.Cmd {
 			if !ok {
 				return watcherErrorMsg{err: fmt.Errorf("watcher events channel closed")}
 			}
+			if m.progressPath != "" && event.Name == m.progressPath {
+				return progressChangedMsg{}
+			}
 			if m.shouldProcessFileEvent(event) {
 				return fileChangedMsg{path: event.Name}
 			}
@@ -733,8 +1014,13 @@ func (m *Model) shouldProcessFileEvent(event watcher.Event) bool {
 		return false
 	}
 
+	// Package exercises are watched as a unit: any file under their directory counts
+	if m.currentExercise.IsPackage() {
+		return m.currentExercise.OwnsPath(event.Name)
+	}
+
 	// Check if it's the current exercise file
-	return strings.Contains(event.Name, m.currentExercise.Info.Name)
+	return strings.Contains(event.Name, m.currentExercise.Info.Name) || m.currentExercise.OwnsPath(event.Name)
 }
 
 // consumeCount returns the pending count (or the default if no count was entered) and resets it.
@@ -901,6 +1187,14 @@ func (m *Model) getFilteredExercises() []*exercise.Exercise {
 			filtered = append(filtered, ex)
 			continue
 		}
+
+		// Check tags
+		for _, tag := range ex.Metadata.Tags {
+			if strings.Contains(strings.ToLower(tag), filterLower) {
+				filtered = append(filtered, ex)
+				break
+			}
+		}
 	}
 
 	return filtered
@@ -928,6 +1222,51 @@ func (m *Model) scrollOutput(delta int) tea.Cmd {
 	return nil
 }
 
+// refreshExercises picks up the manager's exercises after progress was reloaded,
+// keeping the current exercise selected when it's still in scope
+func (m *Model) refreshExercises() {
+	m.exercises = m.exerciseManager.GetExercises()
+	for i, ex := range m.exercises {
+		if ex == m.currentExercise {
+			m.currentIndex = i
+			return
+		}
+	}
+	if m.currentIndex >= len(m.exercises) {
+		m.currentIndex = max(len(m.exercises)-1, 0)
+	}
+	if len(m.exercises) > 0 {
+		m.currentExercise = m.exercises[m.currentIndex]
+	}
+}
+
+// openStats builds the statistics report from the current progress and shows it
+func (m *Model) openStats() {
+	var report strings.Builder
+	if err := stats.WriteText(&report, stats.Build(m.exerciseManager, time.Now(), statsDays)); err != nil {
+		report.WriteString(err.Error())
+	}
+	m.statsLines = strings.Split(strings.TrimRight(report.String(), "\n"), "\n")
+	m.statsScrollPos = 0
+	m.viewMode = ViewStats
+}
+
+// scrollStats scrolls the stats view by the given delta
+func (m *Model) scrollStats(delta int) {
+	maxScroll := max(0, len(m.statsLines)-m.statsViewHeight())
+	m.statsScrollPos += delta
+	if m.statsScrollPos < 0 {
+		m.statsScrollPos = 0
+	} else if m.statsScrollPos > maxScroll {
+		m.statsScrollPos = maxScroll
+	}
+}
+
+// statsViewHeight is the number of report lines the stats view has room for
+func (m *Model) statsViewHeight() int {
+	return max(m.height-listReservedHeight, minListHeight)
+}
+
 // syncExercises validates all exercises and updates progress
 func (m *Model) syncExercises() tea.Cmd {
 	return func() tea.Msg {
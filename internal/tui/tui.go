@@ -44,6 +44,9 @@ func RunTUIWithNotice(exerciseManager *exercise.ExerciseManager, runner *runner.
 
 	// Check if we need to show any final messages
 	if m, ok := finalModel.(*Model); ok {
+		m.trackTime()
+		m.flushTime()
+
 		if m.getCompletedCount() == m.getTotalCount() {
 			fmt.Println("🎉 Congratulations on completing all exercises!")
 		}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/stonecharioteer/goforgo/internal/exercise"
+	"github.com/stonecharioteer/goforgo/internal/runner"
 )
 
 // Layout and animation constants.
@@ -16,6 +18,7 @@ const (
 	maxContentWidth     = 90
 	borderCharWidth     = 80
 	progressBarWidth    = 30
+	statsDays           = 14 // Days in the stats view's activity sparkline
 	splashFrameCount    = 8
 	splashTickMs        = 250
 	listReservedHeight  = 8
@@ -237,6 +240,9 @@ func (m *Model) renderHeader() string {
 		headerStyle.Render("🚀 GoForGo - Interactive Go Tutorial"),
 		progressBar,
 		progressBarStyle.Render(progressText))
+	if track := m.exerciseManager.ActiveTrack(); track != nil {
+		header += statusStyle.Render("  🧭 Track: " + track.Title)
+	}
 
 	return header
 }
@@ -268,9 +274,21 @@ func (m *Model) renderExerciseInfo() string {
 		titleStyle.Render(ex.Description.Title),
 		filePath,
 		difficulty,
-		statusStyle.Render(fmt.Sprintf("(Exercise %d of %d)", m.currentIndex+1, m.getTotalCount())),
+		statusStyle.Render(fmt.Sprintf("(Exercise %d of %d)", m.currentIndex+1, len(m.exercises))),
 		ex.Description.Summary)
 
+	if !ex.IsAvailable() {
+		info += "\n\n" + errorStyle.Render("🚫 Unavailable: "+ex.Unavailable)
+	} else if !ex.IsUnlocked() {
+		var missing []string
+		for _, req := range ex.Requires {
+			if !req.Completed {
+				missing = append(missing, req.Info.Category+"/"+req.Info.Name)
+			}
+		}
+		info += "\n\n" + hintStyle.Render("🔒 Locked: complete "+strings.Join(missing, ", ")+" first")
+	}
+
 	if len(ex.Description.LearningObjectives) > 0 {
 		info += "\n\n🎯 Learning Objectives:"
 		for _, objective := range ex.Description.LearningObjectives {
@@ -330,9 +348,34 @@ func (m *Model) renderResults() string {
 			result.WriteString("\n\n")
 		}
 
-		if m.lastResult.Output != "" {
+		if tests := m.lastResult.Validation.Tests; len(tests) > 0 {
+			hidden := m.currentExercise != nil && len(m.currentExercise.HiddenTests) > 0
+			if hidden {
+				result.WriteString("🔒 Hidden tests:\n")
+			} else {
+				result.WriteString("🧪 Tests:\n")
+			}
+			result.WriteString(renderTestChecklist(tests))
+			result.WriteString("\n")
+			if !hidden || m.currentExercise.Validation.HiddenDetail == runner.HiddenDetailFull {
+				result.WriteString(statusStyle.Render("Press 's' for the full output of every failing test."))
+			} else {
+				result.WriteString(statusStyle.Render("These tests are graded against the reference solution and can't be edited."))
+			}
+			result.WriteString("\n\n")
+			if funcs := m.lastResult.Validation.FuncCoverage; len(funcs) > 0 && m.currentExercise != nil {
+				result.WriteString("📊 Coverage:\n")
+				result.WriteString(renderCoverage(m.lastResult.Validation, m.currentExercise.Validation))
+				result.WriteString("\n\n")
+			}
+		} else if m.lastResult.Output != "" {
 			result.WriteString("🔨 Output:\n")
-			result.WriteString(codeStyle.Render(m.lastResult.Output))
+			if len(m.lastResult.Validation.OutputDiff) > 0 {
+				lines := runner.ColorizeDiffLines(strings.Split(m.lastResult.Output, "\n"))
+				result.WriteString(strings.Join(lines, "\n"))
+			} else {
+				result.WriteString(codeStyle.Render(m.lastResult.Output))
+			}
 			result.WriteString("\n\n")
 		}
 
@@ -366,8 +409,11 @@ func (m *Model) renderFooter() string {
 		"[p] prev",
 		"[h] hint",
 		"[l] list",
+		"[i] stats",
 		"[r] run",
 		"[s] output",
+		"[e] edit",
+		"[f] format",
 		autoAdvanceLabel,
 		skipTodoLabel,
 		"[q] quit",
@@ -530,17 +576,31 @@ func (m *Model) renderHint() string {
 		progressInfo = "Press 'h' to hide hint"
 	}
 
+	// Related exercises, numbered so the learner can jump to them
+	var related string
+	if len(m.currentExercise.Related) > 0 {
+		var links []string
+		for i, ex := range m.currentExercise.Related {
+			if i == 9 {
+				break
+			}
+			links = append(links, fmt.Sprintf("  [%d] %s/%s: %s", i+1, ex.Info.Category, ex.Info.Name, ex.Description.Title))
+		}
+		related = "\n\n🔗 Related exercises (press a number to open):\n" + strings.Join(links, "\n")
+	}
+
 	content := fmt.Sprintf(`%s
 
 📝 Exercise: %s
 
-%s
+%s%s
 
 %s
 %s`,
 		headerStyle.Render("💡 Hints"),
 		titleStyle.Render(m.currentExercise.Description.Title),
 		hintStyle.Render(hintText),
+		statusStyle.Render(related),
 		statusStyle.Render(progressInfo),
 		statusStyle.Render("Press Enter or Esc to return"))
 
@@ -563,6 +623,49 @@ func (m *Model) renderHint() string {
 	return style.Render(borderedContent)
 }
 
+// renderStats shows the learning statistics from the progress history
+func (m *Model) renderStats() string {
+	height := m.statsViewHeight()
+	start := m.statsScrollPos
+	end := min(start+height, len(m.statsLines))
+
+	var scrollInfo []string
+	if start > 0 {
+		scrollInfo = append(scrollInfo, "↑ more above")
+	}
+	if end < len(m.statsLines) {
+		scrollInfo = append(scrollInfo, "↓ more below")
+	}
+	controls := "Press 'i', Enter or Esc to return"
+	if len(scrollInfo) > 0 {
+		controls = strings.Join(scrollInfo, " • ") + " (↑↓/jk to scroll) • " + controls
+	}
+
+	content := fmt.Sprintf(`%s
+
+%s
+
+%s`,
+		headerStyle.Render("📊 Statistics"),
+		strings.Join(m.statsLines[start:end], "\n"),
+		statusStyle.Render(controls))
+
+	// Apply consistent border styling
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#58a6ff"))
+	borderLine := borderStyle.Render(strings.Repeat("═", borderCharWidth))
+
+	borderedContent := fmt.Sprintf(`%s
+%s
+%s`, borderLine, content, borderLine)
+
+	style := lipgloss.NewStyle().
+		Width(m.getContentWidth()).
+		Align(lipgloss.Left).
+		Padding(1, 2)
+
+	return style.Render(borderedContent)
+}
+
 // renderExerciseList shows a scrollable exercise list with proper navigation
 func (m *Model) renderExerciseList() string {
 	var content strings.Builder
@@ -720,6 +823,10 @@ func (m *Model) renderExerciseList() string {
 		status := "Incomplete"
 		if ex.Completed {
 			status = "Complete"
+		} else if !ex.IsAvailable() {
+			status = "Unavailable"
+		} else if !ex.IsUnlocked() {
+			status = "Locked"
 		}
 
 		row := rowData{
@@ -848,6 +955,10 @@ func (m *Model) renderExerciseList() string {
 					rowData := rows[row-1]
 					if rowData.status == "Complete" {
 						return baseStyle.Foreground(lipgloss.Color("#3fb950")) // Green
+					} else if rowData.status == "Unavailable" {
+						return baseStyle.Foreground(lipgloss.Color("#8b949e")) // Gray
+					} else if rowData.status == "Locked" {
+						return baseStyle.Foreground(lipgloss.Color("#d29922")) // Orange
 					} else {
 						return baseStyle.Foreground(lipgloss.Color("#f85149")) // Red
 					}
@@ -1034,6 +1145,9 @@ func (m *Model) renderOutput() string {
 			// Show visible output lines
 			if startLine < totalLines {
 				visibleLines := outputLines[startLine:endLine]
+				if len(m.lastResult.Validation.OutputDiff) > 0 {
+					visibleLines = runner.ColorizeDiffLines(visibleLines)
+				}
 				outputContent := strings.Join(visibleLines, "\n")
 
 				// Style the output in a code block
@@ -1092,6 +1206,85 @@ func (m *Model) renderOutput() string {
 	return style.Render(borderedContent)
 }
 
+// renderTestChecklist renders test results as a nested checklist. Only the first
+// failing test is expanded, so the learner sees exactly which case to fix next.
+func renderTestChecklist(tests []*runner.TestResult) string {
+	first := runner.FirstFailure(tests)
+
+	var lines []string
+	var walk func(tests []*runner.TestResult, depth int)
+	walk = func(tests []*runner.TestResult, depth int) {
+		for _, test := range tests {
+			indent := strings.Repeat("  ", depth)
+			line := fmt.Sprintf("%s%s %s", indent, runner.TestStatusIcon(test.Status), test.ShortName())
+
+			switch test.Status {
+			case runner.TestPass:
+				lines = append(lines, successStyle.Render(line))
+			case runner.TestSkip:
+				lines = append(lines, statusStyle.Render(line))
+			default:
+				lines = append(lines, errorStyle.Render(line))
+			}
+
+			if test == first {
+				for _, out := range strings.Split(strings.TrimSpace(test.Output), "\n") {
+					if strings.TrimSpace(out) != "" {
+						lines = append(lines, codeStyle.Render(indent+"    "+strings.TrimSpace(out)))
+					}
+				}
+			}
+			walk(test.Subtests, depth+1)
+		}
+	}
+	walk(tests, 1)
+
+	return strings.Join(lines, "\n")
+}
+
+// renderCoverage lists per-function coverage, marking the functions the exercise
+// requires and the overall target, so an empty test can't pass unnoticed.
+func renderCoverage(v runner.ValidationResult, targets exercise.ExerciseValidation) string {
+	required := make(map[string]bool, len(targets.MustCover))
+	for _, name := range targets.MustCover {
+		required[name] = true
+	}
+
+	width := 0
+	for _, fc := range v.FuncCoverage {
+		width = max(width, len(fc.Name))
+	}
+
+	var lines []string
+	for _, fc := range v.FuncCoverage {
+		marker := "  "
+		if required[fc.Name] {
+			marker = "🎯"
+		}
+		line := fmt.Sprintf("  %s %-*s %6.1f%%", marker, width, fc.Name, fc.Percent())
+
+		switch {
+		case fc.Covered == 0 && required[fc.Name]:
+			lines = append(lines, errorStyle.Render(line+"  never executed"))
+		case fc.Covered == fc.Total:
+			lines = append(lines, successStyle.Render(line))
+		default:
+			lines = append(lines, statusStyle.Render(line))
+		}
+	}
+
+	total := fmt.Sprintf("  Total: %.1f%% of statements", v.Coverage)
+	if targets.MinCoverage > 0 {
+		total += fmt.Sprintf(" (need %.1f%%)", targets.MinCoverage)
+	}
+	if v.Coverage >= targets.MinCoverage {
+		lines = append(lines, successStyle.Render(total))
+	} else {
+		lines = append(lines, errorStyle.Render(total))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Helper functions
 func max(a, b int) int {
 	if a > b {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/stonecharioteer/goforgo/internal/exercise"
@@ -76,6 +77,7 @@ func (ur *UniversalRunner) convertLegacyResult(legacyResult *runner.Result, ex *
 		ValidationResults: make(map[string]*RuleResult),
 		Environment:       make(map[string]string),
 		Error:             legacyResult.Error,
+		FailureKind:       runner.FailureKind(ex, legacyResult),
 	}
 
 	// Convert validation details to rule results
@@ -108,6 +110,29 @@ func (ur *UniversalRunner) convertLegacyValidationDetails(validation runner.Vali
 		details["test_success"] = validation.TestSuccess
 		details["test_output"] = validation.TestOutput
 	}
+	if len(validation.Tests) > 0 {
+		details["tests"] = validation.Tests
+	}
+	if validation.FuzzOutput != "" {
+		details["fuzz_success"] = validation.FuzzSuccess
+	}
+	if len(validation.FuzzCrashers) > 0 {
+		details["fuzz_crashers"] = validation.FuzzCrashers
+	}
+	if len(validation.Mutants) > 0 {
+		details["mutation_success"] = validation.MutationSuccess
+		details["mutation_score"] = validation.MutationScore
+		details["mutants"] = validation.Mutants
+	}
+	if len(validation.FuncCoverage) > 0 {
+		details["coverage_success"] = validation.CoverageSuccess
+		details["coverage"] = validation.Coverage
+		details["func_coverage"] = validation.FuncCoverage
+	}
+	if len(validation.Benchmarks) > 0 {
+		details["bench_success"] = validation.BenchSuccess
+		details["benchmarks"] = validation.Benchmarks
+	}
 
 	if validation.RunOutput != "" {
 		details["run_success"] = validation.RunSuccess
@@ -118,12 +143,31 @@ func (ur *UniversalRunner) convertLegacyValidationDetails(validation runner.Vali
 		details["static_success"] = validation.StaticSuccess
 		details["static_output"] = validation.StaticOutput
 	}
+	if len(validation.StaticResults) > 0 {
+		details["static_results"] = validation.StaticResults
+	}
+
+	if len(validation.QualityFindings) > 0 {
+		details["quality_success"] = validation.QualitySuccess
+		details["quality_findings"] = validation.QualityFindings
+	}
 
 	if validation.TodoOutput != "" {
 		details["todo_check"] = validation.TodoCheck
 		details["todo_output"] = validation.TodoOutput
 	}
 
+	if len(validation.OutputDiff) > 0 {
+		details["output_diff"] = validation.OutputDiff
+	}
+
+	if validation.SandboxMode != "" {
+		details["sandbox_mode"] = validation.SandboxMode
+		if validation.KilledReason != "" {
+			details["killed_reason"] = validation.KilledReason
+		}
+	}
+
 	return details
 }
 
@@ -152,7 +196,9 @@ func (ur *UniversalRunner) FormatValidationResult(result *ValidationResult) stri
 	for ruleName, ruleResult := range result.ValidationResults {
 		if !ruleResult.Passed {
 			output += fmt.Sprintf("🔴 Rule %s (%s): %s\n", ruleName, ruleResult.RuleType, ruleResult.Error)
-			if ruleResult.Output != "" {
+			if hasLegacyOutputDiff(ruleResult) {
+				output += "   Output:\n" + colorizeDiff(ruleResult.Output) + "\n"
+			} else if ruleResult.Output != "" {
 				output += fmt.Sprintf("   Output: %s\n", ruleResult.Output)
 			}
 		}
@@ -203,3 +249,22 @@ func (ur *UniversalRunner) Cleanup(ctx context.Context) error {
 	// Clean up resources from universal validation system
 	return ur.testOrchestrator.resourceManager.Cleanup(ctx)
 }
+
+// hasLegacyOutputDiff reports whether a legacy rule result carries an expected/actual output diff
+func hasLegacyOutputDiff(ruleResult *RuleResult) bool {
+	details, ok := ruleResult.Details.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	diff, _ := details["output_diff"].([]runner.DiffLine)
+	return len(diff) > 0
+}
+
+// colorizeDiff renders output containing a unified diff with terminal colours
+func colorizeDiff(output string) string {
+	lines := runner.ColorizeDiffLines(strings.Split(output, "\n"))
+	for i, line := range lines {
+		lines[i] = "   " + line
+	}
+	return strings.Join(lines, "\n")
+}
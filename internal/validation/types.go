@@ -102,6 +102,7 @@ type ValidationResult struct {
 	ValidationResults map[string]*RuleResult    `json:"validation_results"`
 	Environment       map[string]string         `json:"environment"`
 	Error             string                    `json:"error,omitempty"`
+	FailureKind       string                    `json:"failure_kind,omitempty"` // Which step failed, see runner.FailureKind
 	Logs              []string                  `json:"logs,omitempty"`
 }
 
@@ -0,0 +1,21 @@
+#!/bin/bash
+
+# Builds internal/deps/modcache.zip, the module bundle embedded by
+# `go build -tags goforgo_bundle`. The zip is a GOPROXY file tree, so a
+# goforgo binary built with it can resolve every exercise dependency offline.
+
+set -euo pipefail
+
+repo_root="$(cd "$(dirname "$0")/.." && pwd)"
+out="$repo_root/internal/deps/modcache.zip"
+work="$(mktemp -d)"
+trap 'rm -rf "$work"' EXIT
+
+echo "📦 Downloading modules into a clean module cache..."
+(cd "$repo_root" && GOMODCACHE="$work/mod" GOFLAGS=-mod=mod go mod download all)
+
+echo "🗜️  Writing $out..."
+rm -f "$out"
+(cd "$work/mod/cache/download" && zip -qr "$out" . -x '*.lock' -x '*/sumdb/*' -x 'sumdb/*')
+
+echo "✅ Bundle ready ($(du -h "$out" | cut -f1)). Build with: go build -tags goforgo_bundle ./cmd/goforgo"
@@ -62,7 +62,7 @@ while IFS= read -r -d '' exercise_file; do
         complete_sets=$((complete_sets + 1))
     fi
     
-done < <(find ./exercises -name "*.go" -type f -print0 | sort -z)
+done < <(find ./exercises -maxdepth 2 -name "*.go" -type f -print0 | sort -z)
 
 # Check for orphaned solutions (solutions without exercises)
 echo "🔍 Checking for orphaned solutions..."
@@ -84,7 +84,7 @@ while IFS= read -r -d '' solution_file; do
         orphaned_solutions+=("$rel_path")
     fi
     
-done < <(find ./solutions -name "*.go" -type f -print0 | sort -z)
+done < <(find ./solutions -maxdepth 2 -name "*.go" -type f -print0 | sort -z)
 
 # Count exercises by category
 echo "📋 Exercise count by category:"
@@ -108,7 +108,7 @@ while IFS= read -r -d '' exercise_file; do
         printf '%s\n' "$category" >> "$category_counts_file"
     fi
     
-done < <(find ./exercises -name "*.go" -type f -print0 | sort -z)
+done < <(find ./exercises -maxdepth 2 -name "*.go" -type f -print0 | sort -z)
 
 # Display category counts
 sort "$category_counts_file" | uniq -c | while read -r count category; do
@@ -9,4 +9,4 @@ func main() {
 	message := "Welcome to Go!"
 	fmt.Println(message)
 	fmt.Println("Current time:", time.Now())
-}
\ No newline at end of file
+}
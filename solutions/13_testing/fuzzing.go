@@ -0,0 +1,19 @@
+// fuzzing.go - SOLUTION
+// Learn fuzz testing with testing.F
+
+package main
+
+import "fmt"
+
+// Reverse returns s with its characters in reverse order
+func Reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func main() {
+	fmt.Println(Reverse("Hello, Gopher!"))
+}
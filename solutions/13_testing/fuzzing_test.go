@@ -0,0 +1,25 @@
+// fuzzing_test.go - SOLUTION
+// Fuzz target for Reverse
+
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzReverse(f *testing.F) {
+	// Seed inputs. More seeds live in testdata/fuzz/FuzzReverse.
+	f.Add("hello")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		rev := Reverse(s)
+		if utf8.ValidString(s) && Reverse(rev) != s {
+			t.Errorf("Reverse(Reverse(%q)) = %q, want the original", s, Reverse(rev))
+		}
+		if utf8.ValidString(s) && !utf8.ValidString(rev) {
+			t.Errorf("Reverse(%q) = %q is not valid UTF-8", s, rev)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// stack_queue_hidden_test.go
+// Reference tests for stack_queue.go. GoForGo copies this file next to the
+// learner's code at validation time; it is not part of the exercise.
+
+package main
+
+import "testing"
+
+func TestStack(t *testing.T) {
+	s := NewStack()
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Fatalf("new stack: IsEmpty() = %v, Size() = %d; want true, 0", s.IsEmpty(), s.Size())
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Error("Pop on an empty stack should return an error")
+	}
+	if _, err := s.Peek(); err == nil {
+		t.Error("Peek on an empty stack should return an error")
+	}
+
+	for _, v := range []int{1, 2, 3} {
+		s.Push(v)
+	}
+	if got, err := s.Peek(); err != nil || got != 3 {
+		t.Errorf("Peek() = %d, %v; want 3, nil", got, err)
+	}
+	if s.Size() != 3 {
+		t.Errorf("Size() after Peek = %d; want 3", s.Size())
+	}
+	for _, want := range []int{3, 2, 1} {
+		if got, err := s.Pop(); err != nil || got != want {
+			t.Errorf("Pop() = %d, %v; want %d, nil", got, err, want)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Error("stack should be empty after popping every element")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue()
+	if _, err := q.Dequeue(); err == nil {
+		t.Error("Dequeue on an empty queue should return an error")
+	}
+	if _, err := q.Front(); err == nil {
+		t.Error("Front on an empty queue should return an error")
+	}
+
+	for _, v := range []int{1, 2, 3} {
+		q.Enqueue(v)
+	}
+	if got, err := q.Front(); err != nil || got != 1 {
+		t.Errorf("Front() = %d, %v; want 1, nil", got, err)
+	}
+	for _, want := range []int{1, 2, 3} {
+		if got, err := q.Dequeue(); err != nil || got != want {
+			t.Errorf("Dequeue() = %d, %v; want %d, nil", got, err, want)
+		}
+	}
+	if !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("drained queue: IsEmpty() = %v, Size() = %d; want true, 0", q.IsEmpty(), q.Size())
+	}
+}
+
+func TestCircularQueue(t *testing.T) {
+	cq := NewCircularQueue(3)
+	for _, v := range []int{1, 2, 3} {
+		if err := cq.Enqueue(v); err != nil {
+			t.Fatalf("Enqueue(%d) = %v; want nil", v, err)
+		}
+	}
+	if !cq.IsFull() {
+		t.Error("queue at capacity should report IsFull")
+	}
+	if err := cq.Enqueue(4); err == nil {
+		t.Error("Enqueue on a full queue should return an error")
+	}
+
+	t.Run("wraparound", func(t *testing.T) {
+		if got, _ := cq.Dequeue(); got != 1 {
+			t.Errorf("Dequeue() = %d; want 1", got)
+		}
+		if err := cq.Enqueue(4); err != nil {
+			t.Fatalf("Enqueue after Dequeue = %v; want nil", err)
+		}
+		for _, want := range []int{2, 3, 4} {
+			if got, err := cq.Dequeue(); err != nil || got != want {
+				t.Errorf("Dequeue() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := cq.Dequeue(); err == nil {
+			t.Error("Dequeue on an empty queue should return an error")
+		}
+	})
+}
+
+func TestDeque(t *testing.T) {
+	d := NewDeque()
+	if _, err := d.PopFront(); err == nil {
+		t.Error("PopFront on an empty deque should return an error")
+	}
+	if _, err := d.PopBack(); err == nil {
+		t.Error("PopBack on an empty deque should return an error")
+	}
+
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	if got, _ := d.Front(); got != 1 {
+		t.Errorf("Front() = %d; want 1", got)
+	}
+	if got, _ := d.Back(); got != 3 {
+		t.Errorf("Back() = %d; want 3", got)
+	}
+	if got, _ := d.PopBack(); got != 3 {
+		t.Errorf("PopBack() = %d; want 3", got)
+	}
+	if got, _ := d.PopFront(); got != 1 {
+		t.Errorf("PopFront() = %d; want 1", got)
+	}
+	if d.Size() != 1 {
+		t.Errorf("Size() = %d; want 1", d.Size())
+	}
+}
@@ -0,0 +1,43 @@
+// Package inventory tracks stock levels by item name.
+package inventory
+
+import "sort"
+
+// Inventory holds the quantity of each item
+type Inventory struct {
+	stock map[string]int
+}
+
+// New returns an empty inventory
+func New() *Inventory {
+	return &Inventory{stock: make(map[string]int)}
+}
+
+// Add increases the quantity of an item
+func (inv *Inventory) Add(name string, quantity int) {
+	inv.stock[name] += quantity
+}
+
+// Items returns item names in alphabetical order
+func (inv *Inventory) Items() []string {
+	names := make([]string, 0, len(inv.stock))
+	for name := range inv.stock {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Quantity returns the stock of a single item
+func (inv *Inventory) Quantity(name string) int {
+	return inv.stock[name]
+}
+
+// Total returns the combined stock of all items
+func (inv *Inventory) Total() int {
+	total := 0
+	for _, quantity := range inv.stock {
+		total += quantity
+	}
+	return total
+}
@@ -0,0 +1,28 @@
+// main.go - SOLUTION
+// Learn how a Go program is split across packages
+
+package main
+
+import (
+	"fmt"
+
+	"goforgo/47_code_organization/package_layout/internal/inventory"
+)
+
+func main() {
+	fmt.Println("=== Package Layout ===")
+
+	inv := inventory.New()
+	inv.Add("apple", 5)
+	inv.Add("banana", 2)
+	inv.Add("cherry", 9)
+
+	items := inv.Items()
+	fmt.Printf("Added %d items\n", len(items))
+
+	for _, name := range items {
+		fmt.Printf("%s: %d\n", name, inv.Quantity(name))
+	}
+
+	fmt.Printf("Total stock: %d\n", inv.Total())
+}